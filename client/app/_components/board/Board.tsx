@@ -23,6 +23,7 @@ export default function Board({ boardId, adminKey, isAdmin = false }: BoardProps
     board,
     isConnected,
     typingUsers,
+    userId,
     addTile,
     revealTile,
     revealAllTiles,
@@ -31,6 +32,7 @@ export default function Board({ boardId, adminKey, isAdmin = false }: BoardProps
     updateColumn,
     deleteColumn,
     addThread,
+    updateActionItem,
     startTyping,
     stopTyping,
   } = useBoardSocket(boardId, adminKey)
@@ -72,6 +74,34 @@ export default function Board({ boardId, adminKey, isAdmin = false }: BoardProps
     }
   }
 
+  // Exports are downloaded; reports open in a new tab, ready to print
+  const downloadExport = async (choice: string) => {
+    const [resource, format] = choice.split(':')
+    try {
+      const response = await fetch(`${process.env.NEXT_PUBLIC_API_URL}/api/boards/${boardId}/${resource}?format=${format}`, {
+        headers: { Authorization: `Bearer ${adminKey}` },
+      })
+      if (!response.ok) {
+        throw new Error(await response.text())
+      }
+
+      const url = URL.createObjectURL(await response.blob())
+      if (resource === 'report') {
+        window.open(url, '_blank')
+        return
+      }
+
+      const link = document.createElement('a')
+      link.download = `retro-board-${boardId}-${new Date().toISOString().split('T')[0]}.${format}`
+      link.href = url
+      link.click()
+      URL.revokeObjectURL(url)
+    } catch (exportError) {
+      console.error('Failed to export board:', exportError)
+      error('Failed to export board. Please try again.')
+    }
+  }
+
   const handleCreateColumn = () => {
     const title = prompt('Enter column title:')
     if (title?.trim()) {
@@ -146,6 +176,20 @@ export default function Board({ boardId, adminKey, isAdmin = false }: BoardProps
 
             {isAdmin && (
               <>
+                <select
+                  value=""
+                  onChange={(e) => downloadExport(e.target.value)}
+                  className="bg-green-600 hover:bg-green-700 text-white px-3 py-2 rounded-lg text-sm font-medium transition-colors"
+                  title="Download the retro outcome"
+                >
+                  <option value="" disabled>Download as…</option>
+                  <option value="export:md">Markdown</option>
+                  <option value="export:csv">CSV</option>
+                  <option value="export:json">JSON</option>
+                  <option value="report:html">Report (HTML)</option>
+                  <option value="report:pdf">Report (PDF)</option>
+                </select>
+
                 <button
                   onClick={revealAllTiles}
                   className="bg-yellow-600 hover:bg-yellow-700 text-white px-4 py-2 rounded-lg text-sm font-medium transition-colors flex items-center space-x-2"
@@ -197,7 +241,9 @@ export default function Board({ boardId, adminKey, isAdmin = false }: BoardProps
                 onDeleteColumn={deleteColumn}
                 onStartTyping={startTyping}
                 onStopTyping={stopTyping}
-                currentUserId={boardId} // Simple user ID based on board access
+                currentUserId={userId ?? undefined}
+                reviewItems={column.review ? (board.actionItems ?? []).filter((item) => item.carriedOverFrom) : undefined}
+                onUpdateActionStatus={(actionId, status) => updateActionItem(actionId, { status })}
               />
             ))}
           </div>
@@ -1,7 +1,7 @@
 'use client'
 
 import { useState, useRef } from 'react'
-import { Column as ColumnType, Tile as TileType } from '@/hooks/useBoardSocket'
+import { ActionItem, ActionItemStatus, Column as ColumnType, Tile as TileType } from '@/hooks/useBoardSocket'
 import { validateTileContent, validateAuthorName, validateColumnTitle, sanitizeInput, getCharacterCount, MAX_TILE_CONTENT_LENGTH, MAX_AUTHOR_NAME_LENGTH } from '@/utils/validation'
 import Tile from './Tile'
 
@@ -17,6 +17,8 @@ interface ColumnProps {
   onStartTyping: () => void
   onStopTyping: () => void
   currentUserId?: string
+  reviewItems?: ActionItem[]
+  onUpdateActionStatus?: (actionId: string, status: ActionItemStatus) => void
 }
 
 export default function Column({
@@ -30,7 +32,9 @@ export default function Column({
   onDeleteColumn,
   onStartTyping,
   onStopTyping,
-  currentUserId
+  currentUserId,
+  reviewItems = [],
+  onUpdateActionStatus
 }: ColumnProps) {
   const [isAddingTile, setIsAddingTile] = useState(false)
   const [newTileContent, setNewTileContent] = useState('')
@@ -97,7 +101,10 @@ export default function Column({
   )
 
   return (
-    <div className="bg-gradient-to-br from-gray-50 to-gray-100 dark:from-gray-900 dark:to-gray-800 rounded-xl p-5 min-h-[600px] flex flex-col shadow-sm border border-gray-200 dark:border-gray-700">
+    <div
+      className="bg-gradient-to-br from-gray-50 to-gray-100 dark:from-gray-900 dark:to-gray-800 rounded-xl p-5 min-h-[600px] flex flex-col shadow-sm border border-gray-200 dark:border-gray-700"
+      style={column.color ? { borderTopColor: column.color, borderTopWidth: 4 } : undefined}
+    >
       {/* Column Header */}
       <div className="flex items-center justify-between mb-4">
         {isEditingTitle ? (
@@ -142,6 +149,32 @@ export default function Column({
         </div>
       </div>
 
+      {/* Carried-over action items, whose status is reviewed here */}
+      {column.review && reviewItems.length > 0 && (
+        <ul className="mb-4 space-y-2">
+          {reviewItems.map((item) => (
+            <li
+              key={item.id}
+              className="flex items-center justify-between bg-white dark:bg-dark-card border border-gray-200 dark:border-dark-border rounded-lg px-3 py-2 text-sm text-gray-900 dark:text-gray-100"
+            >
+              <span className="flex-1 mr-2">
+                {item.title}
+                {item.owner && <span className="text-gray-500 dark:text-gray-400"> — {item.owner}</span>}
+              </span>
+              <select
+                value={item.status}
+                onChange={(e) => onUpdateActionStatus?.(item.id, e.target.value as ActionItemStatus)}
+                className="bg-gray-100 dark:bg-gray-800 border border-gray-300 dark:border-gray-600 rounded px-2 py-1 text-xs"
+              >
+                <option value="open">Open</option>
+                <option value="in_progress">In progress</option>
+                <option value="done">Done</option>
+              </select>
+            </li>
+          ))}
+        </ul>
+      )}
+
       {/* Add Tile Button */}
       <div className="mb-4">
         {!isAddingTile ? (
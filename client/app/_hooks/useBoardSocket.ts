@@ -20,17 +20,100 @@ export interface Tile {
   createdAt: string
 }
 
+export interface Group {
+  id: string
+  title: string
+  tileIds: string[]
+  voterIds: string[]
+  totalVotes: number
+  createdAt: string
+}
+
 export interface Column {
   id: string
   title: string
   order: number
+  color?: string
   tiles: Tile[]
+  groups?: Group[]
+  review?: boolean // lists the action items carried over from the last retro
+}
+
+export interface VotingSettings {
+  votesPerParticipant: number // 0 means unlimited
+  maxVotesPerTile: number
+  allowSelfVote: boolean
+}
+
+export interface VotesRemaining {
+  limit: number
+  used: number
+  remaining: number // -1 when unlimited
+}
+
+export interface Timer {
+  running: boolean
+  deadline: string // when the timer runs out, while running
+  remainingMs: number // time left, while paused
+  durationMs: number
+  autoAdvance: boolean
+}
+
+// Time left on a timer, using the server clock so every client counts down together
+export const timerRemainingMs = (timer: Timer, clockOffsetMs: number): number => {
+  if (!timer.running) return timer.remainingMs
+  return Math.max(0, Date.parse(timer.deadline) - (Date.now() + clockOffsetMs))
+}
+
+export type ActionItemStatus = 'open' | 'in_progress' | 'done'
+
+export interface ActionItem {
+  id: string
+  title: string
+  owner: string
+  dueDate?: string // YYYY-MM-DD
+  status: ActionItemStatus
+  sourceTileId?: string
+  carriedOverFrom?: string // board an open item was carried over from
+  createdAt: string
+  updatedAt: string
+}
+
+export type Phase = 'brainstorm' | 'group' | 'vote' | 'discuss' | 'action_items'
+
+export interface TemplateColumn {
+  title: string
+  color?: string
+}
+
+export interface Template {
+  id: string
+  name: string
+  description?: string
+  columns: TemplateColumn[]
+  voting: VotingSettings
+  phases: Phase[]
+  icebreakerPrompt?: string
+  teamId?: string // team that owns a saved template
+  builtIn?: boolean
+  editKey?: string // only returned to whoever saved the template
 }
 
 export interface Board {
   id: string
-  adminKey: string
   columns: Record<string, Column>
+  voting?: VotingSettings
+  phase?: Phase
+  phases?: Phase[] // the phases this retro runs through, in order
+  timer?: Timer | null
+  serverTime?: string
+  actionItems?: ActionItem[]
+  teamId?: string
+  icebreakerPrompt?: string
+  ttlMinutes?: number // inactivity TTL, when not the server default
+  keepUntil?: string | null // kept at least until then, even if idle
+  expiresAt?: string
+  columnOrder?: string[]
   createdAt: string
   updatedAt: string
 }
@@ -39,31 +122,290 @@ interface BoardState {
   board: Board | null
   isConnected: boolean
   typingUsers: Record<string, boolean>
+  userId: string | null
+  votesRemaining: VotesRemaining | null
+  clockOffsetMs: number // server clock minus local clock
+  expiringSoonAt: string | null // set while the board is about to be deleted
+  closed: { archived: boolean } | null // set once an admin has closed the retro
   setBoard: (board: Board | null) => void
   setConnected: (connected: boolean) => void
   setTypingUsers: (users: Record<string, boolean>) => void
+  setUserId: (userId: string | null) => void
+  setVotesRemaining: (votesRemaining: VotesRemaining | null) => void
+  setClockOffsetMs: (clockOffsetMs: number) => void
+  setExpiringSoonAt: (expiringSoonAt: string | null) => void
+  setClosed: (closed: { archived: boolean } | null) => void
 }
 
 export const useBoardStore = create<BoardState>((set) => ({
   board: null,
   isConnected: false,
   typingUsers: {},
+  userId: null,
+  votesRemaining: null,
+  clockOffsetMs: 0,
+  expiringSoonAt: null,
+  closed: null,
   setBoard: (board) => set({ board }),
   setConnected: (isConnected) => set({ isConnected }),
   setTypingUsers: (typingUsers) => set({ typingUsers }),
+  setUserId: (userId) => set({ userId }),
+  setVotesRemaining: (votesRemaining) => set({ votesRemaining }),
+  setClockOffsetMs: (clockOffsetMs) => set({ clockOffsetMs }),
+  setExpiringSoonAt: (expiringSoonAt) => set({ expiringSoonAt }),
+  setClosed: (closed) => set({ closed }),
 }))
 
+const participantTokenKey = (boardId: string) => `participantToken_${boardId}`
+
+const mapTile = (board: Board, tileId: string, update: (tile: Tile) => Tile): Board => {
+  const columns: Record<string, Column> = {}
+  for (const [id, column] of Object.entries(board.columns)) {
+    columns[id] = {
+      ...column,
+      tiles: column.tiles.map((tile) => (tile.id === tileId ? update(tile) : tile)),
+    }
+  }
+  return { ...board, columns }
+}
+
+// Apply the server's column order, which arrives with every column event
+const withColumnOrder = (board: Board, columnOrder: string[] | undefined): Board => {
+  if (!columnOrder) return board
+  const columns: Record<string, Column> = {}
+  columnOrder.forEach((id, order) => {
+    if (board.columns[id]) columns[id] = { ...board.columns[id], order }
+  })
+  return { ...board, columns, columnOrder }
+}
+
+const upsertTile = (board: Board, columnId: string, tile: Tile): Board => {
+  const column = board.columns[columnId]
+  if (!column) return board
+  const exists = column.tiles.some((t) => t.id === tile.id)
+  return {
+    ...board,
+    columns: {
+      ...board.columns,
+      [columnId]: {
+        ...column,
+        tiles: exists ? column.tiles.map((t) => (t.id === tile.id ? tile : t)) : [...column.tiles, tile],
+      },
+    },
+  }
+}
+
+// Recompute group totals from the votes we can see; while votes are hidden
+// that is only our own
+const refreshGroupVotes = (column: Column): Column => ({
+  ...column,
+  groups: (column.groups ?? []).map((group) => ({
+    ...group,
+    totalVotes: group.voterIds.length + group.tileIds.reduce(
+      (sum, id) => sum + (column.tiles.find((tile) => tile.id === id)?.voterIds.length ?? 0), 0),
+  })),
+})
+
+// Drop a tile from its group the way the server does, dissolving emptied groups
+const ungroupTile = (column: Column, tileId: string): Column => {
+  const groups = (column.groups ?? [])
+    .map((group) => ({ ...group, tileIds: group.tileIds.filter((id) => id !== tileId) }))
+    .filter((group) => group.tileIds.length > 0)
+  return refreshGroupVotes({ ...column, groups })
+}
+
+const upsertGroup = (board: Board, columnId: string, group: Group): Board => {
+  const column = board.columns[columnId]
+  if (!column) return board
+  const groups = column.groups ?? []
+  const exists = groups.some((g) => g.id === group.id)
+  return {
+    ...board,
+    columns: {
+      ...board.columns,
+      [columnId]: refreshGroupVotes({
+        ...column,
+        groups: exists ? groups.map((g) => (g.id === group.id ? group : g)) : [...groups, group],
+      }),
+    },
+  }
+}
+
+// applyBoardEvent returns the board with a server delta applied, or null if
+// the event is not understood and a full resync is needed
+export function applyBoardEvent(board: Board, type: string, payload: any): Board | null {
+  switch (type) {
+    case 'server:tile:created':
+    case 'server:tile:updated':
+    case 'server:tile:revealed':
+      return upsertTile(board, payload.columnId, payload.tile)
+
+    case 'server:tiles:revealed':
+      return payload.tiles.reduce(
+        (acc: Board, entry: { columnId: string; tile: Tile }) => upsertTile(acc, entry.columnId, entry.tile),
+        board,
+      )
+
+    case 'server:tile:deleted': {
+      const column = board.columns[payload.columnId]
+      if (!column) return board
+      return {
+        ...board,
+        columns: {
+          ...board.columns,
+          [payload.columnId]: ungroupTile(
+            { ...column, tiles: column.tiles.filter((tile) => tile.id !== payload.tileId) },
+            payload.tileId,
+          ),
+        },
+      }
+    }
+
+    case 'server:tile:moved': {
+      const from = board.columns[payload.fromColumnId]
+      const to = board.columns[payload.toColumnId]
+      const tile = from?.tiles.find((t) => t.id === payload.tileId)
+      if (!from || !to || !tile) return null
+      const remaining = { ...from, tiles: from.tiles.filter((t) => t.id !== payload.tileId) }
+      const columns = {
+        ...board.columns,
+        // Groups never span columns, so the tile leaves its group when it changes column
+        [from.id]: from.id === to.id ? remaining : ungroupTile(remaining, payload.tileId),
+      }
+      const targetTiles = [...columns[to.id].tiles]
+      targetTiles.splice(payload.index, 0, tile)
+      columns[to.id] = { ...columns[to.id], tiles: targetTiles }
+      return { ...board, columns }
+    }
+
+    case 'server:vote:changed': {
+      const next = mapTile(board, payload.tileId, (tile) => ({ ...tile, voterIds: payload.voterIds }))
+      if (!payload.groupId) return next
+      const columns: Record<string, Column> = {}
+      for (const [id, column] of Object.entries(next.columns)) {
+        columns[id] = refreshGroupVotes(column)
+      }
+      return { ...next, columns }
+    }
+
+    case 'server:voting:updated':
+      return { ...board, voting: payload }
+
+    case 'server:board:retention_updated':
+      return { ...board, ttlMinutes: payload.ttlMinutes, keepUntil: payload.keepUntil, expiresAt: payload.expiresAt }
+
+    // A full snapshot follows, since the phase decides which votes we see
+    case 'server:phase:changed':
+      return { ...board, phase: payload.phase, timer: null }
+
+    case 'server:action:created':
+    case 'server:action:updated': {
+      const items = board.actionItems ?? []
+      const item: ActionItem = payload.actionItem
+      const exists = items.some((i) => i.id === item.id)
+      return { ...board, actionItems: exists ? items.map((i) => (i.id === item.id ? item : i)) : [...items, item] }
+    }
+
+    case 'server:action:deleted':
+      return { ...board, actionItems: (board.actionItems ?? []).filter((i) => i.id !== payload.actionId) }
+
+    case 'server:timer:updated':
+      return { ...board, timer: payload.timer }
+
+    case 'server:timer:expired':
+      return { ...board, phase: payload.phase, timer: null }
+
+    case 'server:group:created':
+    case 'server:group:updated':
+      return upsertGroup(board, payload.columnId, payload.group)
+
+    case 'server:group:dissolved': {
+      const column = board.columns[payload.columnId]
+      if (!column) return board
+      return {
+        ...board,
+        columns: {
+          ...board.columns,
+          [payload.columnId]: { ...column, groups: (column.groups ?? []).filter((g) => g.id !== payload.groupId) },
+        },
+      }
+    }
+
+    case 'server:thread:created':
+      return mapTile(board, payload.tileId, (tile) => ({ ...tile, threads: [...tile.threads, payload.thread] }))
+
+    case 'server:column:created':
+    case 'server:column:updated': {
+      const existing = board.columns[payload.columnId]
+      return withColumnOrder({
+        ...board,
+        columns: {
+          ...board.columns,
+          [payload.columnId]: {
+            id: payload.columnId,
+            title: payload.title,
+            order: payload.order,
+            tiles: existing?.tiles ?? [],
+            groups: existing?.groups ?? [],
+          },
+        },
+      }, payload.columnOrder)
+    }
+
+    case 'server:column:deleted': {
+      const { [payload.columnId]: _removed, ...columns } = board.columns
+      return withColumnOrder({ ...board, columns }, payload.columnOrder)
+    }
+
+    case 'server:columns:reordered':
+      return withColumnOrder(board, payload.columnOrder)
+
+    default:
+      return null
+  }
+}
+
 export interface UseBoardSocketReturn {
   isConnected: boolean
   board: Board | null
   typingUsers: Record<string, boolean>
+  userId: string | null
+  votesRemaining: VotesRemaining | null
+  clockOffsetMs: number
+  expiringSoonAt: string | null
+  closed: { archived: boolean } | null
   addTile: (columnId: string, content: string, author?: string) => void
+  updateTile: (tileId: string, content: string) => void
+  deleteTile: (tileId: string) => void
+  moveTile: (tileId: string, columnId: string, index: number) => void
   revealTile: (tileId: string) => void
   revealAllTiles: () => void
-  voteTile: (tileId: string) => void
+  voteTile: (tileId: string, remove?: boolean) => void
+  updateVotingSettings: (settings: VotingSettings) => void
+  saveBoardTemplate: (name: string, description?: string, icebreakerPrompt?: string) => void
+  extendBoard: (minutes: number) => void
+  keepBoardUntil: (keepUntil: string | null) => void
+  closeBoard: () => void
+  advancePhase: (phase?: Phase) => void
+  startTimer: (durationSeconds: number, autoAdvance?: boolean) => void
+  resumeTimer: () => void
+  pauseTimer: () => void
+  extendTimer: (seconds: number) => void
+  cancelTimer: () => void
+  createActionItem: (title: string, owner?: string, dueDate?: string) => void
+  promoteTile: (tileId: string, owner?: string, dueDate?: string) => void
+  updateActionItem: (actionId: string, changes: Partial<Pick<ActionItem, 'title' | 'owner' | 'dueDate' | 'status'>>) => void
+  deleteActionItem: (actionId: string) => void
   createColumn: (title: string) => void
   updateColumn: (columnId: string, title: string) => void
   deleteColumn: (columnId: string) => void
+  reorderColumns: (columnIds: string[]) => void
+  createGroup: (columnId: string, title: string, tileIds: string[]) => void
+  renameGroup: (groupId: string, title: string) => void
+  addTileToGroup: (groupId: string, tileId: string) => void
+  removeTileFromGroup: (groupId: string, tileId: string) => void
+  dissolveGroup: (groupId: string) => void
+  voteGroup: (groupId: string, remove?: boolean) => void
   addThread: (tileId: string, content: string, author?: string) => void
   startTyping: () => void
   stopTyping: () => void
@@ -73,8 +415,13 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
   const wsRef = useRef<WebSocket | null>(null)
   const reconnectTimeoutRef = useRef<NodeJS.Timeout | null>(null)
   const reconnectAttemptsRef = useRef(0)
+  const lastSeqRef = useRef(0)
+  const closedRef = useRef(false) // the board is gone; do not reconnect
   const maxReconnectAttempts = 5
-  const { board, isConnected, typingUsers, setBoard, setConnected, setTypingUsers } = useBoardStore()
+  const {
+    board, isConnected, typingUsers, userId, votesRemaining, clockOffsetMs, expiringSoonAt, closed,
+    setBoard, setConnected, setTypingUsers, setUserId, setVotesRemaining, setClockOffsetMs, setExpiringSoonAt, setClosed,
+  } = useBoardStore()
 
   const sendMessage = (type: string, payload: any) => {
     if (wsRef.current?.readyState === WebSocket.OPEN) {
@@ -86,9 +433,38 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
 
   useEffect(() => {
     if (!boardId) return
+    lastSeqRef.current = 0 // A new board always starts from a full snapshot
+    closedRef.current = false
+    setClosed(null)
 
-    const wsUrl = `${process.env.NEXT_PUBLIC_WS_URL?.replace('http', 'ws')}/ws?boardId=${boardId}${adminKey ? `&adminKey=${adminKey}` : ''}`
+    const buildWsUrl = (resume: boolean) => {
+      // Present the stored participant token so our user ID survives reconnects
+      const participantToken = localStorage.getItem(participantTokenKey(boardId))
+      return `${process.env.NEXT_PUBLIC_WS_URL?.replace('http', 'ws')}/ws?boardId=${boardId}${adminKey ? `&adminKey=${adminKey}` : ''}${participantToken ? `&participantToken=${encodeURIComponent(participantToken)}` : ''}${resume ? '&resume=1' : ''}`
+    }
     
+    // Apply a sequenced board event, asking for a full snapshot if we missed one
+    const handleBoardEvent = (message: { type: string; seq: number; payload: any }) => {
+      if (message.seq <= lastSeqRef.current) {
+        return // Already reflected in our state
+      }
+
+      const current = useBoardStore.getState().board
+      const next = current && message.seq === lastSeqRef.current + 1
+        ? applyBoardEvent(current, message.type, message.payload)
+        : null
+
+      if (!next) {
+        sendMessage('client:sync:request', {})
+        return
+      }
+
+      lastSeqRef.current = message.seq
+      setBoard(next)
+      // Every change pushes the board's expiry back
+      setExpiringSoonAt(null)
+    }
+
     const connect = () => {
       if (reconnectAttemptsRef.current >= maxReconnectAttempts) {
         console.error('Max reconnection attempts reached')
@@ -96,12 +472,17 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
       }
 
       try {
-        wsRef.current = new WebSocket(wsUrl)
+        // After a drop, catch up from our last sequence instead of a full snapshot
+        const resume = lastSeqRef.current > 0 && useBoardStore.getState().board !== null
+        wsRef.current = new WebSocket(buildWsUrl(resume))
 
         wsRef.current.onopen = () => {
           console.log('WebSocket connected')
           setConnected(true)
           reconnectAttemptsRef.current = 0 // Reset on successful connection
+          if (resume) {
+            sendMessage('client:sync:resume', { lastSeq: lastSeqRef.current })
+          }
         }
 
         wsRef.current.onclose = (event) => {
@@ -109,7 +490,7 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
           setConnected(false)
           
           // Only reconnect if it wasn't a deliberate close
-          if (event.code !== 1000 && reconnectAttemptsRef.current < maxReconnectAttempts) {
+          if (event.code !== 1000 && !closedRef.current && reconnectAttemptsRef.current < maxReconnectAttempts) {
             const delay = Math.min(1000 * Math.pow(2, reconnectAttemptsRef.current), 10000) // Exponential backoff, max 10s
             reconnectAttemptsRef.current++
             
@@ -125,14 +506,46 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
         }
 
         wsRef.current.onmessage = (event) => {
+        // The server may batch several messages into one frame, one per line
+        const lines = String(event.data).split('\n').filter((line) => line.trim() !== '')
+        for (const line of lines) {
         try {
-          const message = JSON.parse(event.data)
+          const message = JSON.parse(line)
+
+          // Snapshots and timer events carry the server clock
+          if (message.payload?.serverTime) {
+            setClockOffsetMs(Date.parse(message.payload.serverTime) - Date.now())
+          }
           
           switch (message.type) {
             case 'server:board:state_update':
+              lastSeqRef.current = message.seq ?? 0
               setBoard(message.payload)
               break
             
+            case 'server:board:closed':
+              closedRef.current = true
+              setClosed(message.payload)
+              break
+
+            case 'server:board:expiring_soon':
+              setExpiringSoonAt(message.payload.expiresAt)
+              break
+
+            case 'server:votes:remaining':
+              setVotesRemaining(message.payload)
+              break
+
+            case 'server:template:saved':
+              // Keep the edit key so this browser can change the template later
+              localStorage.setItem(`templateEditKey_${message.payload.id}`, message.payload.editKey)
+              break
+
+            case 'server:participant:identity':
+              localStorage.setItem(participantTokenKey(boardId), message.payload.token)
+              setUserId(message.payload.userId)
+              break
+
             case 'server:user:is_typing':
               const { userId, typing } = message.payload
               setTypingUsers({
@@ -152,11 +565,16 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
               break
               
             default:
-              console.warn('Unknown WebSocket message type:', message.type)
+              if (typeof message.seq === 'number') {
+                handleBoardEvent(message)
+              } else {
+                console.warn('Unknown WebSocket message type:', message.type)
+              }
           }
         } catch (error) {
           console.error('Error parsing WebSocket message:', error)
         }
+        }
       }
       } catch (error) {
         console.error('Failed to create WebSocket:', error)
@@ -174,12 +592,24 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
         wsRef.current.close(1000, 'Component unmounting')
       }
     }
-  }, [boardId, adminKey, setBoard, setConnected, setTypingUsers])
+  }, [boardId, adminKey, setBoard, setConnected, setTypingUsers, setUserId])
 
   const addTile = (columnId: string, content: string, author = '') => {
     sendMessage('client:tile:create', { columnId, content, author })
   }
 
+  const updateTile = (tileId: string, content: string) => {
+    sendMessage('client:tile:update', { tileId, content })
+  }
+
+  const deleteTile = (tileId: string) => {
+    sendMessage('client:tile:delete', { tileId })
+  }
+
+  const moveTile = (tileId: string, columnId: string, index: number) => {
+    sendMessage('client:tile:move', { tileId, columnId, index })
+  }
+
   const revealTile = (tileId: string) => {
     sendMessage('client:tile:reveal', { tileId })
   }
@@ -188,8 +618,68 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
     sendMessage('client:board:reveal_all', {})
   }
 
-  const voteTile = (tileId: string) => {
-    sendMessage('client:tile:vote', { tileId })
+  const voteTile = (tileId: string, remove = false) => {
+    sendMessage('client:tile:vote', { tileId, remove })
+  }
+
+  const updateVotingSettings = (settings: VotingSettings) => {
+    sendMessage('client:board:voting_settings', settings)
+  }
+
+  const saveBoardTemplate = (name: string, description = '', icebreakerPrompt = '') => {
+    sendMessage('client:board:save_template', { name, description, icebreakerPrompt })
+  }
+
+  const extendBoard = (minutes: number) => {
+    sendMessage('client:board:extend', { minutes })
+  }
+
+  const keepBoardUntil = (keepUntil: string | null) => {
+    sendMessage('client:board:keep_until', { keepUntil })
+  }
+
+  const closeBoard = () => {
+    sendMessage('client:board:close', {})
+  }
+
+  const advancePhase = (phase?: Phase) => {
+    sendMessage('client:phase:advance', phase ? { phase } : {})
+  }
+
+  const startTimer = (durationSeconds: number, autoAdvance = false) => {
+    sendMessage('client:timer:start', { durationSeconds, autoAdvance })
+  }
+
+  const resumeTimer = () => {
+    sendMessage('client:timer:start', {})
+  }
+
+  const pauseTimer = () => {
+    sendMessage('client:timer:pause', {})
+  }
+
+  const extendTimer = (seconds: number) => {
+    sendMessage('client:timer:extend', { seconds })
+  }
+
+  const cancelTimer = () => {
+    sendMessage('client:timer:cancel', {})
+  }
+
+  const createActionItem = (title: string, owner = '', dueDate = '') => {
+    sendMessage('client:action:create', { title, owner, dueDate })
+  }
+
+  const promoteTile = (tileId: string, owner = '', dueDate = '') => {
+    sendMessage('client:action:promote', { tileId, owner, dueDate })
+  }
+
+  const updateActionItem = (actionId: string, changes: Partial<Pick<ActionItem, 'title' | 'owner' | 'dueDate' | 'status'>>) => {
+    sendMessage('client:action:update', { actionId, ...changes })
+  }
+
+  const deleteActionItem = (actionId: string) => {
+    sendMessage('client:action:delete', { actionId })
   }
 
   const createColumn = (title: string) => {
@@ -204,6 +694,34 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
     sendMessage('client:column:delete', { columnId })
   }
 
+  const reorderColumns = (columnIds: string[]) => {
+    sendMessage('client:column:reorder', { columnIds })
+  }
+
+  const createGroup = (columnId: string, title: string, tileIds: string[]) => {
+    sendMessage('client:group:create', { columnId, title, tileIds })
+  }
+
+  const renameGroup = (groupId: string, title: string) => {
+    sendMessage('client:group:rename', { groupId, title })
+  }
+
+  const addTileToGroup = (groupId: string, tileId: string) => {
+    sendMessage('client:group:add_tile', { groupId, tileId })
+  }
+
+  const removeTileFromGroup = (groupId: string, tileId: string) => {
+    sendMessage('client:group:remove_tile', { groupId, tileId })
+  }
+
+  const dissolveGroup = (groupId: string) => {
+    sendMessage('client:group:dissolve', { groupId })
+  }
+
+  const voteGroup = (groupId: string, remove = false) => {
+    sendMessage('client:group:vote', { groupId, remove })
+  }
+
   const addThread = (tileId: string, content: string, author = '') => {
     sendMessage('client:thread:create', { tileId, content, author })
   }
@@ -220,13 +738,43 @@ export function useBoardSocket(boardId: string, adminKey?: string): UseBoardSock
     isConnected,
     board,
     typingUsers,
+    userId,
+    votesRemaining,
+    clockOffsetMs,
+    expiringSoonAt,
+    closed,
     addTile,
+    updateTile,
+    deleteTile,
+    moveTile,
     revealTile,
     revealAllTiles,
     voteTile,
+    updateVotingSettings,
+    saveBoardTemplate,
+    extendBoard,
+    keepBoardUntil,
+    closeBoard,
+    advancePhase,
+    startTimer,
+    resumeTimer,
+    pauseTimer,
+    extendTimer,
+    cancelTimer,
+    createActionItem,
+    promoteTile,
+    updateActionItem,
+    deleteActionItem,
     createColumn,
     updateColumn,
     deleteColumn,
+    reorderColumns,
+    createGroup,
+    renameGroup,
+    addTileToGroup,
+    removeTileFromGroup,
+    dissolveGroup,
+    voteGroup,
     addThread,
     startTyping,
     stopTyping,
@@ -1,12 +1,23 @@
 'use client'
 
-import { useState } from 'react'
+import { useEffect, useState } from 'react'
 import { useRouter } from 'next/navigation'
+import type { Template } from './_hooks/useBoardSocket'
 
 export default function HomePage() {
   const [loading, setLoading] = useState(false)
+  const [teamId, setTeamId] = useState('')
+  const [templates, setTemplates] = useState<Template[]>([])
+  const [templateId, setTemplateId] = useState('')
   const router = useRouter()
 
+  useEffect(() => {
+    fetch(`${process.env.NEXT_PUBLIC_API_URL}/api/templates`)
+      .then((response) => (response.ok ? response.json() : []))
+      .then(setTemplates)
+      .catch((error) => console.error('Error loading templates:', error))
+  }, [])
+
   const createBoard = async () => {
     setLoading(true)
     try {
@@ -15,6 +26,15 @@ export default function HomePage() {
         headers: {
           'Content-Type': 'application/json',
         },
+        // A team carries its open action items over from its last retro; the
+        // team key returned when the team was created proves membership
+        body: JSON.stringify({
+          ...(teamId.trim() ? { teamId: teamId.trim() } : {}),
+          ...(teamId.trim() && localStorage.getItem(`teamKey_${teamId.trim()}`)
+            ? { teamKey: localStorage.getItem(`teamKey_${teamId.trim()}`) }
+            : {}),
+          ...(templateId ? { template: templateId } : {}),
+        }),
       })
 
       if (!response.ok) {
@@ -25,6 +45,9 @@ export default function HomePage() {
       
       // Store admin key in localStorage for this session
       localStorage.setItem(`adminKey_${data.boardId}`, data.adminKey)
+      if (data.teamKey) {
+        localStorage.setItem(`teamKey_${teamId.trim()}`, data.teamKey)
+      }
       
       // Navigate to admin view
       router.push(`/admin/${data.adminKey}?boardId=${data.boardId}`)
@@ -36,6 +59,32 @@ export default function HomePage() {
     }
   }
 
+  const importBoard = async (file: File) => {
+    setLoading(true)
+    try {
+      const response = await fetch(`${process.env.NEXT_PUBLIC_API_URL}/api/boards/import`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': file.name.toLowerCase().endsWith('.csv') ? 'text/csv' : 'application/json',
+        },
+        body: await file.text(),
+      })
+
+      if (!response.ok) {
+        throw new Error(await response.text())
+      }
+
+      const data = await response.json()
+      localStorage.setItem(`adminKey_${data.boardId}`, data.adminKey)
+      router.push(`/admin/${data.adminKey}?boardId=${data.boardId}`)
+    } catch (error) {
+      console.error('Error importing board:', error)
+      alert(`Failed to import board. ${error instanceof Error ? error.message : ''}`)
+    } finally {
+      setLoading(false)
+    }
+  }
+
   return (
     <main className="min-h-screen flex items-center justify-center bg-dark-bg">
       <div className="text-center space-y-8 p-8">
@@ -47,6 +96,31 @@ export default function HomePage() {
         </div>
         
         <div className="space-y-4">
+          {templates.length > 0 && (
+            <select
+              value={templateId}
+              onChange={(e) => setTemplateId(e.target.value)}
+              className="block mx-auto bg-gray-800 text-white border border-gray-600 rounded-lg px-4 py-2 text-sm focus:outline-none focus:border-blue-500"
+            >
+              <option value="">Default format</option>
+              {templates.map((template) => (
+                <option key={template.id} value={template.id} title={template.description}>
+                  {template.name}
+                </option>
+              ))}
+            </select>
+          )}
+
+          <input
+            type="text"
+            value={teamId}
+            onChange={(e) => setTeamId(e.target.value)}
+            placeholder="Team name (optional)"
+            pattern="[A-Za-z0-9_-]*"
+            maxLength={64}
+            className="block mx-auto bg-gray-800 text-white placeholder-gray-500 border border-gray-600 rounded-lg px-4 py-2 text-sm focus:outline-none focus:border-blue-500"
+          />
+
           <button
             onClick={createBoard}
             disabled={loading}
@@ -54,6 +128,21 @@ export default function HomePage() {
           >
             {loading ? 'Creating Board...' : 'Create New Board'}
           </button>
+
+          <label className="block text-sm text-blue-400 hover:text-blue-300 cursor-pointer">
+            or import a board from a JSON export or CSV
+            <input
+              type="file"
+              accept=".json,.csv,application/json,text/csv"
+              disabled={loading}
+              onChange={(e) => {
+                const file = e.target.files?.[0]
+                if (file) importBoard(file)
+                e.target.value = ''
+              }}
+              className="hidden"
+            />
+          </label>
           
           <div className="text-sm text-gray-400">
             <p>As the creator, you'll get admin privileges to:</p>
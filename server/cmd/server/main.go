@@ -12,11 +12,15 @@ import (
 	"github.com/gorilla/handlers"
 	"golang.org/x/time/rate"
 	"live-retro-server/internal/api"
+	"live-retro-server/internal/archive"
+	"live-retro-server/internal/auth"
 	"live-retro-server/internal/config"
 	"live-retro-server/internal/hub"
 	"live-retro-server/internal/logger"
 	"live-retro-server/internal/middleware"
+	"live-retro-server/internal/models"
 	"live-retro-server/internal/monitoring"
+	"live-retro-server/internal/pubsub"
 	"live-retro-server/internal/store"
 	"strings"
 )
@@ -47,16 +51,51 @@ func main() {
 	logger.Infof("Environment: %s", cfg.Environment)
 	logger.Infof("Port: %s", cfg.Port)
 
-	// Initialize Redis store
-	redisStore := store.NewRedisStore(cfg.RedisURL)
-	defer redisStore.Close()
+	// Boards expire after their TTL without changes, within these limits
+	retention := models.Retention{
+		DefaultTTL: cfg.DefaultBoardTTL,
+		MinTTL:     cfg.MinBoardTTL,
+		MaxTTL:     cfg.MaxBoardTTL,
+	}
+	logger.Infof("Board TTL: %v (per-board between %v and %v)", retention.DefaultTTL, retention.MinTTL, retention.MaxTTL)
+
+	// Initialize board store and the broker that fans events out to replicas
+	var boardStore store.BoardStore
+	var broker pubsub.Broker
+	if cfg.UsesMemoryStore() {
+		logger.Warn("Using in-memory board store; boards will not survive a restart")
+		boardStore = store.NewMemoryStore(retention)
+		broker = pubsub.NewLocalBroker()
+	} else {
+		boardStore = store.NewRedisStore(cfg.RedisURL, retention)
+		broker = pubsub.NewRedisBroker(cfg.RedisURL)
+	}
+	defer boardStore.Close()
+	defer broker.Close()
+
+	// Participant tokens keep user IDs stable across reconnects
+	if cfg.ParticipantTokenSecret == "" {
+		logger.Warn("PARTICIPANT_TOKEN_SECRET not set; participant identities will reset on restart")
+	}
+	tokenSigner := auth.NewTokenSigner(cfg.ParticipantTokenSecret)
+
+	// Archiving is opt-in; without it a board's outcome is lost when it expires
+	var boardArchive archive.Archive
+	if cfg.ArchiveDir != "" {
+		fileArchive, err := archive.NewFileArchive(cfg.ArchiveDir)
+		if err != nil {
+			logger.Fatalf("Failed to open board archive: %v", err)
+		}
+		boardArchive = fileArchive
+		logger.Infof("Archiving boards to %s", cfg.ArchiveDir)
+	}
 
 	// Initialize WebSocket hub
-	wsHub := hub.NewHub(redisStore)
+	wsHub := hub.NewHub(boardStore, tokenSigner, broker, boardArchive, cfg.MaxColumnsPerBoard)
 	go wsHub.Run()
 
 	// Initialize API server
-	server := api.NewServer(redisStore, wsHub)
+	server := api.NewServer(boardStore, wsHub, boardArchive, cfg.MaxColumnsPerBoard)
 
 	// Setup routes
 	mux := http.NewServeMux()
@@ -67,7 +106,11 @@ func main() {
 	
 	// API endpoints
 	mux.HandleFunc("/api/boards", server.CreateBoard)
-	mux.HandleFunc("/api/boards/", server.GetBoard)
+	mux.HandleFunc("/api/boards/import", server.ImportBoard)
+	mux.HandleFunc("/api/boards/", server.Board)
+	mux.HandleFunc("/api/templates", server.Templates)
+	mux.HandleFunc("/api/templates/", server.Template)
+	mux.HandleFunc("/api/archive/", server.GetArchivedBoard)
 	mux.HandleFunc("/ws", server.HandleWebSocket)
 
 	// Create rate limiter
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"live-retro-server/internal/export"
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+	"live-retro-server/internal/store"
+)
+
+// exportBoard renders the board as ?format=md, csv or json. Exports include
+// every vote, so they require "Authorization: Bearer {adminKey}".
+func (s *Server) exportBoard(w http.ResponseWriter, r *http.Request, boardID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	board, ok := s.adminBoard(w, r, boardID)
+	if !ok {
+		return
+	}
+
+	doc := export.NewDocument(board, time.Now())
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", doc.FileName(format)))
+	if err := doc.Write(w, format); err != nil {
+		logger.Errorf("Error exporting board %s: %v", boardID, err)
+	}
+}
+
+// reportBoard renders a printable summary of the board as ?format=html or
+// pdf. Like exports, reports require the admin key.
+func (s *Server) reportBoard(w http.ResponseWriter, r *http.Request, boardID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format, err := export.ParseReportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	board, ok := s.adminBoard(w, r, boardID)
+	if !ok {
+		return
+	}
+
+	report := export.NewReport(board, time.Now())
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", report.FileName(format)))
+	if err := report.Write(w, format); err != nil {
+		logger.Errorf("Error rendering report for board %s: %v", boardID, err)
+	}
+}
+
+// adminBoard loads the board for a request that must carry its admin key as
+// "Authorization: Bearer {adminKey}", writing the error response itself if
+// the board is missing or the key is wrong
+func (s *Server) adminBoard(w http.ResponseWriter, r *http.Request, boardID string) (*models.Board, bool) {
+	board, err := s.store.GetBoard(boardID)
+	if errors.Is(err, store.ErrBoardNotFound) {
+		http.Error(w, "Board not found", http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		logger.Errorf("Error getting board %s: %v", boardID, err)
+		http.Error(w, "Failed to load board", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if adminKey := adminKeyFromRequest(r); adminKey == "" || adminKey != board.AdminKey {
+		http.Error(w, "Invalid admin key", http.StatusForbidden)
+		return nil, false
+	}
+
+	return board, true
+}
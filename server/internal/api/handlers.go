@@ -2,24 +2,33 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"live-retro-server/internal/archive"
 	"live-retro-server/internal/hub"
+	"live-retro-server/internal/logger"
 	"live-retro-server/internal/models"
 	"live-retro-server/internal/store"
 )
 
 type Server struct {
-	store *store.RedisStore
-	hub   *hub.Hub
+	store      store.BoardStore
+	hub        *hub.Hub
+	archive    archive.Archive // nil unless archiving is enabled
+	maxColumns int             // per board, checked on imported boards
 }
 
-func NewServer(store *store.RedisStore, hub *hub.Hub) *Server {
+func NewServer(store store.BoardStore, hub *hub.Hub, archive archive.Archive, maxColumns int) *Server {
 	return &Server{
-		store: store,
-		hub:   hub,
+		store:      store,
+		hub:        hub,
+		archive:    archive,
+		maxColumns: maxColumns,
 	}
 }
 
@@ -29,33 +38,93 @@ func (s *Server) CreateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The body is optional; an empty one creates a standalone board
+	var request models.CreateBoardRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := models.ValidateCreateBoardRequest(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.store.Retention().ValidateTTLMinutes(request.TTLMinutes); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	base, err := s.baseTemplate(request.Template)
+	if errors.Is(err, store.ErrTemplateNotFound) {
+		http.Error(w, "Unknown template: "+request.Template, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error loading template %s: %v", request.Template, err)
+		http.Error(w, "Failed to create board", http.StatusInternalServerError)
+		return
+	}
+	template, err := models.ResolveTemplate(base, &request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(request.Columns) > 0 {
+		// Template text is stored sanitized; only the request's own is not yet
+		models.SanitizeTemplateColumns(template.Columns)
+	}
+
+	carriedOver, team, err := s.carryOverActionItems(&request)
+	if errors.Is(err, store.ErrBoardNotFound) {
+		http.Error(w, "Previous board not found", http.StatusNotFound)
+		return
+	}
+	if err == errInvalidPreviousAdminKey {
+		http.Error(w, "Invalid admin key for the previous board", http.StatusForbidden)
+		return
+	}
+	if err == errInvalidTeamKey {
+		http.Error(w, "Invalid team key", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error loading action items to carry over: %v", err)
+		http.Error(w, "Failed to create board", http.StatusInternalServerError)
+		return
+	}
+
+	// A stored template only starts boards of its own team, whose key or
+	// previous board the request has just proven
+	if base != nil && !base.BuiltIn && (team == nil || team.ID != base.TeamID) {
+		http.Error(w, "Template belongs to another team", http.StatusForbidden)
+		return
+	}
+
 	boardID := uuid.New().String()
 	adminKey := uuid.New().String()
 
-	// Create default columns
-	col1ID := uuid.New().String()
-	col2ID := uuid.New().String()
-	col3ID := uuid.New().String()
-	
-	columns := map[string]*models.Column{
-		col1ID: {
-			ID:    col1ID,
-			Title: "What went well?",
-			Order: 0,
-			Tiles: []*models.Tile{},
-		},
-		col2ID: {
-			ID:    col2ID,
-			Title: "What could be improved?",
-			Order: 1,
-			Tiles: []*models.Tile{},
-		},
-		col3ID: {
-			ID:    col3ID,
-			Title: "Action items",
-			Order: 2,
-			Tiles: []*models.Tile{},
-		},
+	// Create the template's columns, after a review column for any action
+	// items carried over
+	columns := make(map[string]*models.Column, len(template.Columns)+1)
+	if len(carriedOver) > 0 {
+		columnID := uuid.New().String()
+		columns[columnID] = &models.Column{
+			ID:     columnID,
+			Title:  models.ReviewColumnTitle,
+			Tiles:  []*models.Tile{},
+			Groups: []*models.Group{},
+			Review: true,
+		}
+	}
+	for _, column := range template.Columns {
+		columnID := uuid.New().String()
+		columns[columnID] = &models.Column{
+			ID:     columnID,
+			Title:  column.Title,
+			Order:  len(columns),
+			Color:  column.Color,
+			Tiles:  []*models.Tile{},
+			Groups: []*models.Group{},
+		}
 	}
 
 	board := &models.Board{
@@ -64,6 +133,19 @@ func (s *Server) CreateBoard(w http.ResponseWriter, r *http.Request) {
 		Columns:   columns,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Voting:    template.Voting,
+		Phase:     template.Phases[0],
+		Phases:    template.Phases,
+
+		ActionItems: carriedOver,
+
+		IcebreakerPrompt: template.IcebreakerPrompt,
+		TTLMinutes:       request.TTLMinutes,
+	}
+
+	if team != nil {
+		board.TeamID = team.ID
+		board.TeamKey = team.Key
 	}
 
 	if err := s.store.SaveBoard(board); err != nil {
@@ -71,49 +153,233 @@ func (s *Server) CreateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The new board is now the team's latest retro
+	if board.TeamID != "" {
+		if err := s.store.SaveTeam(board.TeamSnapshot()); err != nil {
+			logger.Errorf("Error saving team %s: %v", board.TeamID, err)
+		}
+	}
+
 	response := map[string]string{
 		"boardId":  boardID,
 		"adminKey": adminKey,
 	}
+	if board.TeamKey != "" {
+		response["teamKey"] = board.TeamKey
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-func (s *Server) GetBoard(w http.ResponseWriter, r *http.Request) {
-	boardID := r.URL.Path[len("/api/boards/"):]
+// baseTemplate returns the template a new board starts from, or nil for a
+// custom board
+func (s *Server) baseTemplate(templateID string) (*models.Template, error) {
+	switch templateID {
+	case models.TemplateCustom:
+		return nil, nil
+	case "":
+		templateID = models.DefaultTemplateID
+	}
+	return s.findTemplate(templateID)
+}
+
+// Errors rejecting a new board that names a previous board or team without
+// the key proving the creator may take over its action items
+var (
+	errInvalidPreviousAdminKey = errors.New("invalid previous admin key")
+	errInvalidTeamKey          = errors.New("invalid team key")
+)
+
+// carryOverActionItems returns the unresolved action items a new board takes
+// over, from the previous board if given or else from the team's last retro,
+// along with the team the new board belongs to, if any. Taking over a
+// previous board needs its admin key, and joining an existing team its key.
+func (s *Server) carryOverActionItems(request *models.CreateBoardRequest) ([]*models.ActionItem, *models.Team, error) {
+	if request.PreviousBoardID != "" {
+		previous, err := s.store.GetBoard(request.PreviousBoardID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if request.PreviousAdminKey == "" || request.PreviousAdminKey != previous.AdminKey {
+			return nil, nil, errInvalidPreviousAdminKey
+		}
+		carriedOver := models.UnresolvedActionItems(previous.ActionItems, previous.ID)
+
+		if request.TeamID == "" || request.TeamID == previous.TeamID {
+			team, err := s.continueTeam(previous)
+			return carriedOver, team, err
+		}
+		team, err := s.joinTeam(request.TeamID, request.TeamKey)
+		return carriedOver, team, err
+	}
+
+	if request.TeamID == "" {
+		return []*models.ActionItem{}, nil, nil
+	}
+
+	team, err := s.joinTeam(request.TeamID, request.TeamKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return team.ActionItems, team, nil
+}
+
+// joinTeam returns the team a new board joins after checking its key, or a
+// new team with a fresh key for the team's first retro
+func (s *Server) joinTeam(teamID, teamKey string) (*models.Team, error) {
+	team, err := s.store.GetTeam(teamID)
+	if errors.Is(err, store.ErrTeamNotFound) {
+		return &models.Team{
+			ID:          teamID,
+			Key:         uuid.New().String(),
+			ActionItems: []*models.ActionItem{},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Teams recorded before they had keys can only be continued from a board
+	if team.Key == "" || teamKey != team.Key {
+		return nil, errInvalidTeamKey
+	}
+	return team, nil
+}
+
+// continueTeam returns the team of a previous board, whose admin may start the
+// team's next retro without the team key
+func (s *Server) continueTeam(previous *models.Board) (*models.Team, error) {
+	if previous.TeamID == "" {
+		return nil, nil
+	}
+
+	key := previous.TeamKey
+	team, err := s.store.GetTeam(previous.TeamID)
+	if err == nil && team.Key != "" {
+		key = team.Key
+	} else if err != nil && !errors.Is(err, store.ErrTeamNotFound) {
+		return nil, err
+	}
+	if key == "" {
+		key = uuid.New().String()
+	}
+
+	return &models.Team{ID: previous.TeamID, Key: key}, nil
+}
+
+// Board serves a single board: GET /api/boards/{id} returns it,
+// GET /api/boards/{id}/export renders it for download,
+// GET /api/boards/{id}/report summarises it for printing and the other
+// routes below it change it like the matching WebSocket messages
+func (s *Server) Board(w http.ResponseWriter, r *http.Request) {
+	boardID, resource, _ := strings.Cut(r.URL.Path[len("/api/boards/"):], "/")
 	if boardID == "" {
 		http.Error(w, "Board ID required", http.StatusBadRequest)
 		return
 	}
 
+	switch {
+	case resource == "" && r.Method == http.MethodGet:
+		s.getBoard(w, r, boardID)
+	case resource == "export":
+		s.exportBoard(w, r, boardID)
+	case resource == "report":
+		s.reportBoard(w, r, boardID)
+	default:
+		s.boardOperation(w, r, boardID, resource)
+	}
+}
+
+func (s *Server) getBoard(w http.ResponseWriter, r *http.Request, boardID string) {
 	board, err := s.store.GetBoard(boardID)
 	if err != nil {
 		http.Error(w, "Board not found", http.StatusNotFound)
 		return
 	}
 
+	// The admin key is never echoed back; it only unlocks the admin view
+	role := models.RoleParticipant
+	if adminKey := adminKeyFromRequest(r); adminKey != "" && adminKey == board.AdminKey {
+		role = models.RoleAdmin
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(board)
+	json.NewEncoder(w).Encode(models.ProjectBoard(board, models.Viewer{Role: role}))
+}
+
+// GetArchivedBoard returns the final snapshot of an archived board, filtered
+// like a live board for the requester
+func (s *Server) GetArchivedBoard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	boardID := r.URL.Path[len("/api/archive/"):]
+	if boardID == "" {
+		http.Error(w, "Board ID required", http.StatusBadRequest)
+		return
+	}
+	if s.archive == nil {
+		http.Error(w, "Archiving is not enabled", http.StatusNotFound)
+		return
+	}
+
+	record, err := s.archive.Get(boardID)
+	if errors.Is(err, archive.ErrNotArchived) {
+		http.Error(w, "Board not archived", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error reading archived board %s: %v", boardID, err)
+		http.Error(w, "Failed to load archived board", http.StatusInternalServerError)
+		return
+	}
+
+	role := models.RoleParticipant
+	if adminKey := adminKeyFromRequest(r); adminKey != "" && adminKey == record.Board.AdminKey {
+		role = models.RoleAdmin
+	}
+
+	writeJSON(w, http.StatusOK, models.ArchivedBoard{
+		Board:      models.ProjectBoard(record.Board, models.Viewer{Role: role}),
+		ArchivedAt: record.ArchivedAt,
+		Reason:     record.Reason,
+	})
 }
 
 func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	boardID := r.URL.Query().Get("boardId")
 	adminKey := r.URL.Query().Get("adminKey")
+	participantToken := r.URL.Query().Get("participantToken")
+	role := models.ParseRole(r.URL.Query().Get("role"))
+	resuming := r.URL.Query().Get("resume") == "1"
 
 	if boardID == "" {
 		http.Error(w, "boardId parameter required", http.StatusBadRequest)
 		return
 	}
 
-	s.hub.HandleWebSocket(w, r, boardID, adminKey)
+	s.hub.HandleWebSocket(w, r, boardID, adminKey, participantToken, role, resuming)
+}
+
+// adminKeyFromRequest reads the admin key from an "Authorization: Bearer" header
+func adminKeyFromRequest(r *http.Request) string {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return ""
+	}
+	return strings.TrimSpace(auth[len(prefix):])
 }
 
 func (s *Server) EnableCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+hub.ParticipantTokenHeader)
+		w.Header().Set("Access-Control-Expose-Headers", hub.ParticipantTokenHeader)
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
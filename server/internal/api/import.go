@@ -0,0 +1,157 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"live-retro-server/internal/export"
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+// maxImportBytes bounds the size of an imported board
+const maxImportBytes = 1 << 20
+
+// ImportBoard creates a fresh board from a JSON export or, when sent as
+// text/csv, a CSV of column, content and author. The board gets new IDs and
+// admin key; votes are not carried over.
+func (s *Server) ImportBoard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		http.Error(w, "Import is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var doc *export.Document
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+		doc, err = export.ReadCSV(bytes.NewReader(data))
+	} else {
+		doc, err = export.ReadJSON(bytes.NewReader(data))
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	board := newImportedBoard(doc, time.Now())
+	if err := models.ValidateBoard(board, s.maxColumns); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	models.SanitizeBoard(board)
+
+	if err := s.store.SaveBoard(board); err != nil {
+		logger.Errorf("Error saving imported board: %v", err)
+		http.Error(w, "Failed to import board", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]string{
+		"boardId":  board.ID,
+		"adminKey": board.AdminKey,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// newImportedBoard builds a board in its first phase from the document.
+// Imported tiles start out revealed.
+func newImportedBoard(doc *export.Document, now time.Time) *models.Board {
+	board := &models.Board{
+		ID:          uuid.New().String(),
+		AdminKey:    uuid.New().String(),
+		Columns:     make(map[string]*models.Column, len(doc.Columns)),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Voting:      doc.Voting,
+		Phase:       models.Phases[0],
+		Phases:      append([]models.Phase{}, models.Phases...),
+		ActionItems: make([]*models.ActionItem, 0, len(doc.ActionItems)),
+	}
+	if board.Voting == (models.VotingSettings{}) {
+		board.Voting = models.DefaultVotingSettings()
+	}
+
+	for i, imported := range doc.Columns {
+		column := &models.Column{
+			ID:     uuid.New().String(),
+			Title:  imported.Title,
+			Order:  i,
+			Color:  imported.Color,
+			Tiles:  []*models.Tile{},
+			Groups: []*models.Group{},
+		}
+
+		for _, item := range imported.Items {
+			if !item.IsGroup() {
+				column.Tiles = append(column.Tiles, newImportedTile(&item, now))
+				continue
+			}
+
+			group := &models.Group{
+				ID:        uuid.New().String(),
+				Title:     item.Title,
+				TileIDs:   []string{},
+				VoterIDs:  []string{},
+				CreatedAt: now,
+			}
+			for _, member := range item.Tiles {
+				tile := newImportedTile(&member, now)
+				column.Tiles = append(column.Tiles, tile)
+				group.TileIDs = append(group.TileIDs, tile.ID)
+			}
+			column.Groups = append(column.Groups, group)
+		}
+
+		board.Columns[column.ID] = column
+	}
+
+	for _, imported := range doc.ActionItems {
+		status := imported.Status
+		if status == "" {
+			status = models.ActionOpen
+		}
+		board.ActionItems = append(board.ActionItems, &models.ActionItem{
+			ID:        uuid.New().String(),
+			Title:     imported.Title,
+			Owner:     imported.Owner,
+			DueDate:   imported.DueDate,
+			Status:    status,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	return board
+}
+
+func newImportedTile(item *export.Item, now time.Time) *models.Tile {
+	tile := &models.Tile{
+		ID:        uuid.New().String(),
+		Content:   item.Content,
+		Author:    item.Author,
+		VoterIDs:  []string{},
+		Threads:   make([]*models.Thread, 0, len(item.Threads)),
+		CreatedAt: now,
+	}
+	for _, thread := range item.Threads {
+		tile.Threads = append(tile.Threads, &models.Thread{
+			ID:        uuid.New().String(),
+			Content:   thread.Content,
+			Author:    thread.Author,
+			CreatedAt: now,
+		})
+	}
+	return tile
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"live-retro-server/internal/export"
+	"live-retro-server/internal/models"
+	"live-retro-server/internal/store"
+)
+
+// exportedBoard is the board every round trip starts from. Its text needs
+// escaping when stored.
+func exportedBoard() *export.Document {
+	return &export.Document{
+		Version: export.Version,
+		Voting:  models.VotingSettings{VotesPerParticipant: 5, MaxVotesPerTile: 2, AllowSelfVote: true},
+		Columns: []export.Column{
+			{
+				Title: "Went well",
+				Color: "#22c55e",
+				Items: []export.Item{
+					{Content: "Shipped <b>on time</b> & on budget", Author: "Ana"},
+					{Content: "Pairing\non the = parser"},
+				},
+			},
+			{
+				Title: "To improve",
+				Items: []export.Item{
+					{Content: "Flaky CI", Author: "Bo"},
+				},
+			},
+		},
+		ActionItems: []export.ActionItem{
+			{Title: "Fix CI", Owner: "Bo", DueDate: "2030-01-31", Status: models.ActionOpen},
+			{Title: "Write docs", Status: models.ActionDone},
+		},
+	}
+}
+
+// withGroupsAndThreads adds what only a JSON export carries across
+func withGroupsAndThreads(doc *export.Document) *export.Document {
+	doc.Columns[1].Items = append(doc.Columns[1].Items, export.Item{
+		Title: "Meetings",
+		Tiles: []export.Item{
+			{Content: "Too long", Threads: []export.Thread{{Content: "Agreed", Author: "Cy"}}},
+			{Content: "Too many", Author: "Di"},
+		},
+	})
+	return doc
+}
+
+// withoutColors leaves out the column colors a CSV has no field for
+func withoutColors(doc *export.Document) *export.Document {
+	for i := range doc.Columns {
+		doc.Columns[i].Color = ""
+	}
+	return doc
+}
+
+func TestImportRoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		doc         *export.Document
+		contentType string
+		format      export.Format
+	}{
+		{name: "json", doc: exportedBoard(), contentType: "application/json", format: export.FormatJSON},
+		{name: "json with groups and threads", doc: withGroupsAndThreads(exportedBoard()), contentType: "application/json", format: export.FormatJSON},
+		{name: "csv", doc: withoutColors(exportedBoard()), contentType: "text/csv", format: export.FormatCSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boards := store.NewMemoryStore(models.DefaultRetention())
+			defer boards.Close()
+			server := NewServer(boards, nil, nil, 10)
+
+			var body bytes.Buffer
+			if err := tt.doc.Write(&body, tt.format); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/api/boards/import", &body)
+			request.Header.Set("Content-Type", tt.contentType)
+			recorder := httptest.NewRecorder()
+			server.ImportBoard(recorder, request)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("ImportBoard = %d %s", recorder.Code, recorder.Body.String())
+			}
+
+			var created map[string]string
+			if err := json.NewDecoder(recorder.Body).Decode(&created); err != nil {
+				t.Fatalf("decoding the response: %v", err)
+			}
+			board, err := boards.GetBoard(created["boardId"])
+			if err != nil {
+				t.Fatalf("GetBoard: %v", err)
+			}
+			if board.AdminKey != created["adminKey"] {
+				t.Errorf("stored admin key %q, response has %q", board.AdminKey, created["adminKey"])
+			}
+
+			got := export.NewDocument(board, time.Now())
+			if tt.format == export.FormatJSON && got.Voting != tt.doc.Voting {
+				t.Errorf("voting = %+v, want %+v", got.Voting, tt.doc.Voting)
+			}
+			if !reflect.DeepEqual(got.Columns, tt.doc.Columns) {
+				t.Errorf("columns = %+v, want %+v", got.Columns, tt.doc.Columns)
+			}
+			if !reflect.DeepEqual(got.ActionItems, tt.doc.ActionItems) {
+				t.Errorf("action items = %+v, want %+v", got.ActionItems, tt.doc.ActionItems)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"live-retro-server/internal/hub"
+	"live-retro-server/internal/models"
+)
+
+// maxOperationBytes bounds the body of a board operation request
+const maxOperationBytes = 16 << 10
+
+// operation maps a REST route below /api/boards/{id} to the WebSocket message
+// it sends. Segments of the path written as {name} are copied into the
+// message payload under that name, as are the fields in payload; both take
+// precedence over the request body.
+type operation struct {
+	method  string
+	path    string
+	message string
+	payload map[string]interface{}
+}
+
+var operations = []operation{
+	{http.MethodDelete, "", "client:board:close", nil},
+	{http.MethodPost, "reveal", "client:board:reveal_all", nil},
+	{http.MethodPost, "phase", "client:phase:advance", nil},
+	{http.MethodPut, "voting", "client:board:voting_settings", nil},
+	{http.MethodPost, "extend", "client:board:extend", nil},
+	{http.MethodPut, "keep-until", "client:board:keep_until", nil},
+	{http.MethodPost, "templates", "client:board:save_template", nil},
+
+	{http.MethodPost, "timer", "client:timer:start", nil},
+	{http.MethodPost, "timer/pause", "client:timer:pause", nil},
+	{http.MethodPost, "timer/extend", "client:timer:extend", nil},
+	{http.MethodDelete, "timer", "client:timer:cancel", nil},
+
+	{http.MethodPost, "columns", "client:column:create", nil},
+	{http.MethodPut, "columns/order", "client:column:reorder", nil},
+	{http.MethodPatch, "columns/{columnId}", "client:column:update", nil},
+	{http.MethodDelete, "columns/{columnId}", "client:column:delete", nil},
+	{http.MethodPost, "columns/{columnId}/tiles", "client:tile:create", nil},
+
+	{http.MethodPatch, "tiles/{tileId}", "client:tile:update", nil},
+	{http.MethodDelete, "tiles/{tileId}", "client:tile:delete", nil},
+	{http.MethodPost, "tiles/{tileId}/move", "client:tile:move", nil},
+	{http.MethodPost, "tiles/{tileId}/reveal", "client:tile:reveal", nil},
+	{http.MethodPost, "tiles/{tileId}/votes", "client:tile:vote", nil},
+	{http.MethodDelete, "tiles/{tileId}/votes", "client:tile:vote", map[string]interface{}{"remove": true}},
+	{http.MethodPost, "tiles/{tileId}/threads", "client:thread:create", nil},
+	{http.MethodPost, "tiles/{tileId}/actions", "client:action:promote", nil},
+
+	{http.MethodPost, "groups", "client:group:create", nil},
+	{http.MethodPatch, "groups/{groupId}", "client:group:rename", nil},
+	{http.MethodDelete, "groups/{groupId}", "client:group:dissolve", nil},
+	{http.MethodPost, "groups/{groupId}/tiles/{tileId}", "client:group:add_tile", nil},
+	{http.MethodDelete, "groups/{groupId}/tiles/{tileId}", "client:group:remove_tile", nil},
+	{http.MethodPost, "groups/{groupId}/votes", "client:group:vote", nil},
+	{http.MethodDelete, "groups/{groupId}/votes", "client:group:vote", map[string]interface{}{"remove": true}},
+
+	{http.MethodPost, "actions", "client:action:create", nil},
+	{http.MethodPatch, "actions/{actionId}", "client:action:update", nil},
+	{http.MethodDelete, "actions/{actionId}", "client:action:delete", nil},
+}
+
+// match reports whether path fits the operation's route, returning the
+// values of its {name} segments
+func (o *operation) match(path string) (map[string]string, bool) {
+	want := strings.Split(o.path, "/")
+	got := strings.Split(path, "/")
+	if len(want) != len(got) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range want {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if got[i] == "" {
+				return nil, false
+			}
+			params[segment[1:len(segment)-1]] = got[i]
+			continue
+		}
+		if segment != got[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// boardOperation applies a board change requested over REST. The body is the
+// JSON payload of the matching WebSocket message; admin operations require
+// "Authorization: Bearer {adminKey}".
+func (s *Server) boardOperation(w http.ResponseWriter, r *http.Request, boardID, path string) {
+	var found *operation
+	var params map[string]string
+	pathMatched := false
+	for i := range operations {
+		p, ok := operations[i].match(path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if operations[i].method == r.Method {
+			found, params = &operations[i], p
+			break
+		}
+	}
+	if found == nil {
+		if pathMatched {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		http.NotFound(w, r)
+		return
+	}
+
+	// The body is optional, as many operations need nothing beyond the path
+	payload := make(map[string]interface{})
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOperationBytes)).Decode(&payload); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	for name, value := range params {
+		payload[name] = value
+	}
+	for name, value := range found.payload {
+		payload[name] = value
+	}
+
+	s.hub.HandleRequest(w, r, boardID, adminKeyFromRequest(r), r.Header.Get(hub.ParticipantTokenHeader), models.WebSocketMessage{
+		Type:    found.message,
+		Payload: payload,
+	})
+}
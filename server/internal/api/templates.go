@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+	"live-retro-server/internal/store"
+)
+
+// Templates lists the templates a board can be created from (GET) or stores a
+// new team template (POST). Listing returns the built-in templates followed by
+// those of the team given as ?teamId=. Reading or saving a team's templates
+// requires "Authorization: Bearer {teamKey}".
+func (s *Server) Templates(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.listTemplates(w, r)
+	case http.MethodPost:
+		s.createTemplate(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Template reads (GET), replaces (PUT) or deletes (DELETE) a single template.
+// Reading a stored template requires "Authorization: Bearer {teamKey}" of its
+// team, or its edit key; changing it requires "Authorization: Bearer {editKey}".
+func (s *Server) Template(w http.ResponseWriter, r *http.Request) {
+	templateID := r.URL.Path[len("/api/templates/"):]
+	if templateID == "" {
+		http.Error(w, "Template ID required", http.StatusBadRequest)
+		return
+	}
+
+	template, err := s.findTemplate(templateID)
+	if errors.Is(err, store.ErrTemplateNotFound) {
+		http.Error(w, "Template not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error getting template %s: %v", templateID, err)
+		http.Error(w, "Failed to load template", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		key := adminKeyFromRequest(r)
+		if !template.BuiltIn && (key == "" || key != template.EditKey) && !s.checkTeamKey(w, template.TeamID, key) {
+			return
+		}
+		writeJSON(w, http.StatusOK, template.Public())
+		return
+	}
+	if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if template.BuiltIn {
+		http.Error(w, "Built-in templates cannot be changed", http.StatusForbidden)
+		return
+	}
+	if editKey := adminKeyFromRequest(r); editKey == "" || editKey != template.EditKey {
+		http.Error(w, "Invalid edit key", http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := s.store.DeleteTemplate(templateID); err != nil {
+			logger.Errorf("Error deleting template %s: %v", templateID, err)
+			http.Error(w, "Failed to delete template", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	updated, ok := decodeTemplate(w, r)
+	if !ok {
+		return
+	}
+	updated.ID = template.ID
+	updated.EditKey = template.EditKey
+	updated.TeamID = template.TeamID
+
+	if err := s.store.SaveTemplate(updated); err != nil {
+		logger.Errorf("Error saving template %s: %v", templateID, err)
+		http.Error(w, "Failed to save template", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated.Public())
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request) {
+	templates := make([]*models.Template, 0, len(models.BuiltinTemplates))
+	for _, template := range models.BuiltinTemplates {
+		templates = append(templates, template.Public())
+	}
+
+	teamID := r.URL.Query().Get("teamId")
+	if teamID == "" {
+		writeJSON(w, http.StatusOK, templates)
+		return
+	}
+	if !s.checkTeamKey(w, teamID, adminKeyFromRequest(r)) {
+		return
+	}
+
+	stored, err := s.store.ListTeamTemplates(teamID)
+	if err != nil {
+		logger.Errorf("Error listing templates of team %s: %v", teamID, err)
+		http.Error(w, "Failed to list templates", http.StatusInternalServerError)
+		return
+	}
+	for _, template := range stored {
+		templates = append(templates, template.Public())
+	}
+
+	writeJSON(w, http.StatusOK, templates)
+}
+
+func (s *Server) createTemplate(w http.ResponseWriter, r *http.Request) {
+	template, ok := decodeTemplate(w, r)
+	if !ok {
+		return
+	}
+	if template.TeamID == "" {
+		http.Error(w, "A template must belong to a team", http.StatusBadRequest)
+		return
+	}
+	if !s.checkTeamKey(w, template.TeamID, adminKeyFromRequest(r)) {
+		return
+	}
+
+	stored, err := s.store.ListTeamTemplates(template.TeamID)
+	if err != nil {
+		logger.Errorf("Error listing templates of team %s: %v", template.TeamID, err)
+		http.Error(w, "Failed to save template", http.StatusInternalServerError)
+		return
+	}
+	if len(stored) >= models.MaxTeamTemplates {
+		http.Error(w, fmt.Sprintf("A team may store at most %d templates", models.MaxTeamTemplates), http.StatusConflict)
+		return
+	}
+
+	template.ID = uuid.New().String()
+	template.EditKey = uuid.New().String()
+
+	if err := s.store.SaveTemplate(template); err != nil {
+		logger.Errorf("Error saving template: %v", err)
+		http.Error(w, "Failed to save template", http.StatusInternalServerError)
+		return
+	}
+
+	// The edit key is only ever returned here, to the template's creator
+	writeJSON(w, http.StatusCreated, template)
+}
+
+// checkTeamKey reports whether teamKey is the key of the team, writing the
+// error response itself if not
+func (s *Server) checkTeamKey(w http.ResponseWriter, teamID, teamKey string) bool {
+	team, err := s.store.GetTeam(teamID)
+	if errors.Is(err, store.ErrTeamNotFound) {
+		http.Error(w, "Invalid team key", http.StatusForbidden)
+		return false
+	}
+	if err != nil {
+		logger.Errorf("Error getting team %s: %v", teamID, err)
+		http.Error(w, "Failed to load team", http.StatusInternalServerError)
+		return false
+	}
+	if team.Key == "" || teamKey != team.Key {
+		http.Error(w, "Invalid team key", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// decodeTemplate reads, validates and sanitizes a template from the request
+// body, writing the error response itself if that fails
+func decodeTemplate(w http.ResponseWriter, r *http.Request) (*models.Template, bool) {
+	var request models.SaveTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+	if err := models.ValidateSaveTemplateRequest(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	template := models.NewTemplate(&request)
+	if err := models.ValidateTemplate(template); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	models.SanitizeTemplate(template)
+	return template, true
+}
+
+// findTemplate returns the built-in or stored template with the given ID
+func (s *Server) findTemplate(templateID string) (*models.Template, error) {
+	if template, ok := models.LookupTemplate(templateID); ok {
+		return template, nil
+	}
+	return s.store.GetTemplate(templateID)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
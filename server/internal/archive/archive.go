@@ -0,0 +1,104 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"live-retro-server/internal/models"
+)
+
+// ErrNotArchived is returned when a board has no archived snapshot
+var ErrNotArchived = errors.New("board not archived")
+
+// Archive keeps final snapshots of boards so a retro's outcome survives the
+// board's expiry
+type Archive interface {
+	Save(record *models.ArchivedBoard) error
+	Get(boardID string) (*models.ArchivedBoard, error)
+}
+
+var _ Archive = (*FileArchive)(nil)
+
+// FileArchive stores each archived board as a JSON file in one directory
+type FileArchive struct {
+	dir string
+}
+
+// NewFileArchive archives into dir, creating it if needed
+func NewFileArchive(dir string) (*FileArchive, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %v", err)
+	}
+	return &FileArchive{dir: dir}, nil
+}
+
+// Save writes the snapshot to a temporary file first and renames it into
+// place, so a crash never leaves a half-written archive behind
+func (a *FileArchive) Save(record *models.ArchivedBoard) error {
+	path, err := a.path(record.Board.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal archived board: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(a.dir, ".archive-*")
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write archive file: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write archive file: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store archive file: %v", err)
+	}
+	return nil
+}
+
+func (a *FileArchive) Get(boardID string) (*models.ArchivedBoard, error) {
+	path, err := a.path(boardID)
+	if err != nil {
+		return nil, ErrNotArchived
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotArchived
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive file: %v", err)
+	}
+
+	var record models.ArchivedBoard
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archived board: %v", err)
+	}
+	return &record, nil
+}
+
+// path maps a board ID to its file. Board IDs come from requests, so anything
+// that could escape the archive directory is rejected.
+func (a *FileArchive) path(boardID string) (string, error) {
+	if boardID == "" {
+		return "", fmt.Errorf("invalid board ID")
+	}
+	for _, r := range boardID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return "", fmt.Errorf("invalid board ID")
+		}
+	}
+	return filepath.Join(a.dir, boardID+".json"), nil
+}
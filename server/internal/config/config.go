@@ -12,8 +12,10 @@ type Config struct {
 	Port        string
 	Environment string
 	
-	// Redis config
-	RedisURL string
+	// Storage config
+	StoreBackend string // "redis" or "memory"
+	RedisURL     string
+	ArchiveDir   string // archive boards here before they expire; empty disables. Shared by all replicas
 	
 	// Rate limiting
 	RateLimitRPS   int
@@ -21,12 +23,15 @@ type Config struct {
 	
 	// Board settings
 	DefaultBoardTTL      time.Duration
+	MinBoardTTL          time.Duration // bounds for per-board TTLs
+	MaxBoardTTL          time.Duration // also how far ahead a board can be kept
 	MaxTilesPerColumn    int
 	MaxColumnsPerBoard   int
 	MaxConcurrentConns   int
 	
 	// Security
-	CORSOrigins []string
+	CORSOrigins            []string
+	ParticipantTokenSecret string
 	
 	// Logging
 	LogLevel  string
@@ -37,17 +42,23 @@ func Load() *Config {
 	return &Config{
 		Port:        getEnvOrDefault("PORT", "8080"),
 		Environment: getEnvOrDefault("ENVIRONMENT", "development"),
-		RedisURL:    getEnvOrDefault("REDIS_URL", "redis://localhost:6379"),
+
+		StoreBackend: getEnvOrDefault("STORE_BACKEND", "redis"),
+		RedisURL:     getEnvOrDefault("REDIS_URL", "redis://localhost:6379"),
+		ArchiveDir:   getEnvOrDefault("ARCHIVE_DIR", ""),
 		
 		RateLimitRPS:   getEnvIntOrDefault("RATE_LIMIT_REQUESTS_PER_SECOND", 10),
 		RateLimitBurst: getEnvIntOrDefault("RATE_LIMIT_BURST", 20),
 		
 		DefaultBoardTTL:    time.Duration(getEnvIntOrDefault("DEFAULT_BOARD_TTL_MINUTES", 30)) * time.Minute,
+		MinBoardTTL:        time.Duration(getEnvIntOrDefault("MIN_BOARD_TTL_MINUTES", 5)) * time.Minute,
+		MaxBoardTTL:        time.Duration(getEnvIntOrDefault("MAX_BOARD_TTL_MINUTES", 7*24*60)) * time.Minute,
 		MaxTilesPerColumn:  getEnvIntOrDefault("MAX_TILES_PER_COLUMN", 100),
 		MaxColumnsPerBoard: getEnvIntOrDefault("MAX_COLUMNS_PER_BOARD", 10),
 		MaxConcurrentConns: getEnvIntOrDefault("MAX_CONCURRENT_CONNECTIONS", 1000),
 		
-		CORSOrigins: getEnvArrayOrDefault("CORS_ORIGINS", []string{"http://localhost:3000"}),
+		CORSOrigins:            getEnvArrayOrDefault("CORS_ORIGINS", []string{"http://localhost:3000"}),
+		ParticipantTokenSecret: getEnvOrDefault("PARTICIPANT_TOKEN_SECRET", ""),
 		
 		LogLevel:  getEnvOrDefault("LOG_LEVEL", "info"),
 		LogFormat: getEnvOrDefault("LOG_FORMAT", "text"),
@@ -62,6 +73,10 @@ func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development"
 }
 
+func (c *Config) UsesMemoryStore() bool {
+	return c.StoreBackend == "memory"
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
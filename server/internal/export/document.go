@@ -0,0 +1,149 @@
+package export
+
+import (
+	"html"
+	"time"
+
+	"live-retro-server/internal/models"
+)
+
+// Version identifies the layout of exported documents, so older exports can
+// still be recognised once the layout changes
+const Version = 1
+
+// Document is the portable form of a board that every export format renders.
+// Board text is stored HTML-escaped; a document carries it as plain text.
+type Document struct {
+	Version     int                   `json:"version"`
+	BoardID     string                `json:"boardId"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	ExportedAt  time.Time             `json:"exportedAt"`
+	Phase       models.Phase          `json:"phase"`
+	Voting      models.VotingSettings `json:"voting"`
+	Columns     []Column              `json:"columns"`
+	ActionItems []ActionItem          `json:"actionItems"`
+}
+
+type Column struct {
+	Title string `json:"title"`
+	Color string `json:"color,omitempty"`
+	Items []Item `json:"items"`
+}
+
+// Item is a tile, or a group exported as one item with its tiles nested
+type Item struct {
+	Title   string   `json:"title,omitempty"`   // set for a group
+	Content string   `json:"content,omitempty"` // set for a tile
+	Author  string   `json:"author,omitempty"`
+	Votes   int      `json:"votes"` // a group counts its own and its tiles' votes
+	Threads []Thread `json:"threads,omitempty"`
+	Tiles   []Item   `json:"tiles,omitempty"` // a group's tiles
+}
+
+// IsGroup reports whether the item is a group of tiles
+func (i *Item) IsGroup() bool {
+	return i.Tiles != nil
+}
+
+type Thread struct {
+	Content string `json:"content"`
+	Author  string `json:"author,omitempty"`
+}
+
+type ActionItem struct {
+	Title   string                  `json:"title"`
+	Owner   string                  `json:"owner,omitempty"`
+	DueDate string                  `json:"dueDate,omitempty"`
+	Status  models.ActionItemStatus `json:"status"`
+}
+
+// NewDocument captures the board in a stable order: columns in display order,
+// and items in the order of their tiles, a group taking the place of its first
+// tile. Tiles that have not been revealed are left out.
+func NewDocument(board *models.Board, now time.Time) *Document {
+	doc := &Document{
+		Version:     Version,
+		BoardID:     board.ID,
+		CreatedAt:   board.CreatedAt,
+		ExportedAt:  now,
+		Phase:       board.CurrentPhase(),
+		Voting:      board.VotingSettings(),
+		Columns:     make([]Column, 0, len(board.Columns)),
+		ActionItems: make([]ActionItem, 0, len(board.ActionItems)),
+	}
+
+	for _, column := range board.OrderedColumns() {
+		doc.Columns = append(doc.Columns, newColumn(column))
+	}
+
+	for _, item := range board.ActionItems {
+		doc.ActionItems = append(doc.ActionItems, ActionItem{
+			Title:   text(item.Title),
+			Owner:   text(item.Owner),
+			DueDate: item.DueDate,
+			Status:  item.Status,
+		})
+	}
+
+	return doc
+}
+
+func newColumn(column *models.Column) Column {
+	exported := Column{
+		Title: text(column.Title),
+		Color: column.Color,
+		Items: []Item{},
+	}
+
+	// Index in Items of each group seen so far
+	groups := make(map[string]int, len(column.Groups))
+	for _, tile := range column.Tiles {
+		if tile.IsHidden {
+			continue
+		}
+
+		group := column.GroupOf(tile.ID)
+		if group == nil {
+			exported.Items = append(exported.Items, newTileItem(tile))
+			continue
+		}
+
+		index, ok := groups[group.ID]
+		if !ok {
+			index = len(exported.Items)
+			groups[group.ID] = index
+			exported.Items = append(exported.Items, Item{
+				Title: text(group.Title),
+				Votes: len(group.VoterIDs),
+				Tiles: []Item{},
+			})
+		}
+
+		// Count only the votes of the tiles exported, not of hidden ones
+		item := newTileItem(tile)
+		exported.Items[index].Votes += item.Votes
+		exported.Items[index].Tiles = append(exported.Items[index].Tiles, item)
+	}
+
+	return exported
+}
+
+func newTileItem(tile *models.Tile) Item {
+	item := Item{
+		Content: text(tile.Content),
+		Author:  text(tile.Author),
+		Votes:   len(tile.VoterIDs),
+	}
+	for _, thread := range tile.Threads {
+		item.Threads = append(item.Threads, Thread{
+			Content: text(thread.Content),
+			Author:  text(thread.Author),
+		})
+	}
+	return item
+}
+
+// text undoes the HTML escaping applied when board text was stored
+func text(stored string) string {
+	return html.UnescapeString(stored)
+}
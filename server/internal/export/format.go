@@ -0,0 +1,260 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"live-retro-server/internal/models"
+)
+
+// Format selects how a document is rendered
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatCSV      Format = "csv"
+	FormatJSON     Format = "json"
+)
+
+// ParseFormat returns the format with the given name; empty selects Markdown
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case "":
+		return FormatMarkdown, nil
+	case FormatMarkdown, FormatCSV, FormatJSON:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported export format %q, use md, csv or json", name)
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSON:
+		return "application/json"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+// FileName suggests a name for the exported document
+func (d *Document) FileName(format Format) string {
+	return fmt.Sprintf("retro-%s-%s.%s", d.CreatedAt.Format("2006-01-02"), d.BoardID, format)
+}
+
+// Write renders the document to w in the given format
+func (d *Document) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatCSV:
+		return d.WriteCSV(w)
+	case FormatJSON:
+		return d.WriteJSON(w)
+	}
+	return d.WriteMarkdown(w)
+}
+
+func (d *Document) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(d)
+}
+
+// WriteMarkdown renders the columns as headed lists followed by the action
+// items as a task list
+func (d *Document) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Retro %s\n", d.CreatedAt.Format("2 January 2006"))
+
+	for _, column := range d.Columns {
+		fmt.Fprintf(&b, "\n## %s\n\n", escapeMarkdown(oneLine(column.Title)))
+		if len(column.Items) == 0 {
+			b.WriteString("_No items_\n")
+		}
+		for _, item := range column.Items {
+			if item.IsGroup() {
+				fmt.Fprintf(&b, "- **%s**%s\n", escapeMarkdown(oneLine(item.Title)), votes(item.Votes))
+				for _, tile := range item.Tiles {
+					writeMarkdownTile(&b, &tile, "  ", false)
+				}
+				continue
+			}
+			writeMarkdownTile(&b, &item, "", true)
+		}
+	}
+
+	b.WriteString("\n## Action items\n\n")
+	if len(d.ActionItems) == 0 {
+		b.WriteString("_No action items_\n")
+	}
+	for _, item := range d.ActionItems {
+		check := " "
+		if item.Status == models.ActionDone {
+			check = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s", check, escapeMarkdown(oneLine(item.Title)))
+		if item.Owner != "" {
+			fmt.Fprintf(&b, " (@%s)", escapeMarkdown(oneLine(item.Owner)))
+		}
+		if item.DueDate != "" {
+			fmt.Fprintf(&b, ", due %s", item.DueDate)
+		}
+		fmt.Fprintf(&b, " [%s]\n", item.Status)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownTile writes a tile as a list item at the given indent, with
+// its threads nested below it. Tiles inside a group leave the vote count to
+// the group.
+func writeMarkdownTile(b *strings.Builder, tile *Item, indent string, withVotes bool) {
+	nested := indent + "  "
+
+	fmt.Fprintf(b, "%s- %s", indent, strings.ReplaceAll(escapeMarkdown(tile.Content), "\n", "\n"+nested))
+	if tile.Author != "" {
+		fmt.Fprintf(b, " — _%s_", escapeMarkdown(oneLine(tile.Author)))
+	}
+	if withVotes {
+		b.WriteString(votes(tile.Votes))
+	}
+	b.WriteString("\n")
+
+	for _, thread := range tile.Threads {
+		fmt.Fprintf(b, "%s- 💬 %s", nested, strings.ReplaceAll(escapeMarkdown(thread.Content), "\n", "\n"+nested+"  "))
+		if thread.Author != "" {
+			fmt.Fprintf(b, " — _%s_", escapeMarkdown(oneLine(thread.Author)))
+		}
+		b.WriteString("\n")
+	}
+}
+
+func votes(count int) string {
+	switch count {
+	case 0:
+		return ""
+	case 1:
+		return " (1 vote)"
+	}
+	return fmt.Sprintf(" (%d votes)", count)
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// markdownEscaper escapes HTML and the characters Markdown reads as inline
+// formatting, so board text renders as written and never as markup
+var markdownEscaper = strings.NewReplacer(
+	"&", "&amp;", "<", "&lt;", ">", "&gt;",
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"(", `\(`, ")", `\)`, "#", `\#`, "!", `\!`, "|", `\|`, "~", `\~`,
+)
+
+// escapeMarkdown escapes text for a Markdown document. Besides inline
+// formatting, a line starting like a list item or a heading underline is
+// escaped so multi-line text stays inside its own list item.
+func escapeMarkdown(s string) string {
+	lines := strings.Split(markdownEscaper.Replace(s), "\n")
+	for i, line := range lines {
+		text := strings.TrimLeft(line, " \t")
+		start := len(line) - len(text)
+
+		digits := len(text) - len(strings.TrimLeft(text, "0123456789"))
+		switch {
+		case text != "" && strings.ContainsRune("-+=", rune(text[0])):
+			lines[i] = line[:start] + `\` + text
+		case digits > 0 && digits < len(text) && (text[digits] == '.' || text[digits] == ')'):
+			lines[i] = line[:start+digits] + `\` + text[digits:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// csvHeader names the CSV columns. Every item is one row: a group's content
+// is its title followed by its tiles, one per line. Action items follow the
+// board's items.
+var csvHeader = []string{"type", "column", "content", "author", "votes", "threads", "owner", "dueDate", "status"}
+
+const (
+	csvTypeTile   = "tile"
+	csvTypeGroup  = "group"
+	csvTypeAction = "action"
+)
+
+func (d *Document) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, column := range d.Columns {
+		for _, item := range column.Items {
+			row := []string{csvTypeTile, column.Title, item.Content, item.Author, strconv.Itoa(item.Votes), csvThreads(item.Threads), "", "", ""}
+			if item.IsGroup() {
+				row[0] = csvTypeGroup
+				row[2], row[3], row[5] = csvGroup(&item)
+			}
+			if err := writer.Write(csvRow(row)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, item := range d.ActionItems {
+		row := []string{csvTypeAction, "", item.Title, "", "", "", item.Owner, item.DueDate, string(item.Status)}
+		if err := writer.Write(csvRow(row)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvRow keeps spreadsheets from evaluating cells that start like a formula
+// by prefixing them with an apostrophe
+func csvRow(row []string) []string {
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+			row[i] = "'" + cell
+		}
+	}
+	return row
+}
+
+// csvGroup returns the content, authors and threads of a group's row
+func csvGroup(group *Item) (string, string, string) {
+	lines := []string{group.Title}
+	var authors []string
+	var threads []Thread
+	seen := make(map[string]bool)
+	for _, tile := range group.Tiles {
+		lines = append(lines, "- "+tile.Content)
+		if tile.Author != "" && !seen[tile.Author] {
+			seen[tile.Author] = true
+			authors = append(authors, tile.Author)
+		}
+		threads = append(threads, tile.Threads...)
+	}
+	return strings.Join(lines, "\n"), strings.Join(authors, ", "), csvThreads(threads)
+}
+
+func csvThreads(threads []Thread) string {
+	lines := make([]string, 0, len(threads))
+	for _, thread := range threads {
+		if thread.Author == "" {
+			lines = append(lines, thread.Content)
+			continue
+		}
+		lines = append(lines, thread.Author+": "+thread.Content)
+	}
+	return strings.Join(lines, "\n")
+}
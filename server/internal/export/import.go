@@ -0,0 +1,115 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"live-retro-server/internal/models"
+)
+
+// ReadJSON reads a document written by WriteJSON
+func ReadJSON(r io.Reader) (*Document, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON export: %v", err)
+	}
+
+	if doc.Version != Version {
+		return nil, fmt.Errorf("unsupported export version %d, expected %d", doc.Version, Version)
+	}
+
+	return &doc, nil
+}
+
+// ReadCSV reads a CSV with a header row naming at least the column and
+// content fields; author is optional. Columns are created in the order they
+// first appear. CSVs written by WriteCSV are read back too: their action rows
+// become action items, while a group row becomes a single tile and votes and
+// threads are dropped.
+func ReadCSV(r io.Reader) (*Document, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+
+	fields := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheets often save a byte order mark before the first field
+		name = strings.TrimPrefix(name, "\ufeff")
+		fields[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"column", "content"} {
+		if _, ok := fields[required]; !ok {
+			return nil, fmt.Errorf("the CSV header needs a %q field", required)
+		}
+	}
+
+	doc := &Document{
+		Version:     Version,
+		Columns:     []Column{},
+		ActionItems: []ActionItem{},
+	}
+	columns := make(map[string]int)
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := fields[name]; ok {
+				return csvValue(row[i])
+			}
+			return ""
+		}
+
+		if field("type") == csvTypeAction {
+			doc.ActionItems = append(doc.ActionItems, ActionItem{
+				Title:   field("content"),
+				Owner:   field("owner"),
+				DueDate: field("duedate"),
+				Status:  models.ActionItemStatus(field("status")),
+			})
+			continue
+		}
+
+		title := field("column")
+		if strings.TrimSpace(title) == "" {
+			return nil, fmt.Errorf("line %d: column is required", line)
+		}
+		index, ok := columns[title]
+		if !ok {
+			index = len(doc.Columns)
+			columns[title] = index
+			doc.Columns = append(doc.Columns, Column{Title: title, Items: []Item{}})
+		}
+
+		doc.Columns[index].Items = append(doc.Columns[index].Items, Item{
+			Content: field("content"),
+			Author:  field("author"),
+		})
+	}
+
+	return doc, nil
+}
+
+// csvValue undoes the apostrophe csvRow puts in front of formula-like cells
+func csvValue(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune("=+-@", rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
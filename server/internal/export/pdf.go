@@ -0,0 +1,290 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WritePDF renders the report as an A4 PDF. The PDF is laid out by hand with
+// the standard Helvetica fonts, which need no embedding; characters outside
+// the Windows-1252 set, such as emoji, are printed as "?".
+func (r *Report) WritePDF(w io.Writer) error {
+	pdf := newPDFDocument()
+
+	pdf.heading("Retro report", pdfTitle, "")
+	pdf.text(r.Summary(), pdfBody, false, 0)
+
+	for _, column := range r.Columns {
+		pdf.heading(column.Title, pdfHeading, column.Color)
+		if len(column.Items) == 0 {
+			pdf.text("No items", pdfBody, false, 0)
+		}
+		for _, item := range column.Items {
+			if item.IsGroup() {
+				pdf.text("• "+item.Title+votes(item.Votes), pdfBody, true, 0)
+				for _, tile := range item.Tiles {
+					pdf.tile(&tile, pdfIndent)
+				}
+				continue
+			}
+			pdf.tile(&item, 0)
+		}
+	}
+
+	pdf.heading("Action items", pdfHeading, "")
+	if len(r.ActionItems) == 0 {
+		pdf.text("No action items", pdfBody, false, 0)
+	}
+	for _, item := range r.ActionItems {
+		line := fmt.Sprintf("• [%s] %s", item.Status, item.Title)
+		if item.Owner != "" {
+			line += " — " + item.Owner
+		}
+		if item.DueDate != "" {
+			line += ", due " + item.DueDate
+		}
+		pdf.text(line, pdfBody, false, 0)
+	}
+
+	_, err := w.Write(pdf.bytes())
+	return err
+}
+
+// tile writes a tile with its author and votes, followed by its threads
+func (p *pdfDocument) tile(tile *Item, indent float64) {
+	line := "• " + tile.Content
+	if tile.Author != "" {
+		line += " — " + tile.Author
+	}
+	p.text(line+votes(tile.Votes), pdfBody, false, indent)
+
+	for _, thread := range tile.Threads {
+		line := "> " + thread.Content
+		if thread.Author != "" {
+			line += " — " + thread.Author
+		}
+		p.text(line, pdfSmall, false, indent+pdfIndent)
+	}
+}
+
+const (
+	pdfPageWidth  = 595.0 // A4 in points
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+	pdfIndent     = 16.0
+)
+
+type pdfStyle struct {
+	size    float64
+	leading float64
+}
+
+var (
+	pdfTitle   = pdfStyle{size: 22, leading: 28}
+	pdfHeading = pdfStyle{size: 14, leading: 24}
+	pdfBody    = pdfStyle{size: 10, leading: 14}
+	pdfSmall   = pdfStyle{size: 9, leading: 12}
+)
+
+// pdfDocument collects the content stream of each page while the report is
+// laid out top to bottom
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64 // baseline of the next line on the last page
+}
+
+func newPDFDocument() *pdfDocument {
+	p := &pdfDocument{}
+	p.newPage()
+	return p
+}
+
+func (p *pdfDocument) newPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+	p.y = pdfPageHeight - pdfMargin
+}
+
+// reserve starts a new page unless height points still fit on this one
+func (p *pdfDocument) reserve(height float64) {
+	if p.y-height < pdfMargin {
+		p.newPage()
+	}
+}
+
+// heading writes a bold heading, with a bar in the given color if any. A
+// heading is kept on the same page as the line after it.
+func (p *pdfDocument) heading(title string, style pdfStyle, color string) {
+	p.reserve(style.leading + pdfBody.leading)
+	p.y -= style.leading - style.size
+
+	page := p.pages[len(p.pages)-1]
+	if rgb, ok := pdfColor(color); ok {
+		baseline := p.y - style.leading
+		fmt.Fprintf(page, "%s rg %.1f %.1f 4 %.1f re f 0 g\n", rgb, pdfMargin-10, baseline-style.size*0.2, style.size)
+	}
+	p.line(oneLine(title), style, true, 0)
+}
+
+// text writes a paragraph, wrapping it to the page width
+func (p *pdfDocument) text(text string, style pdfStyle, bold bool, indent float64) {
+	width := pdfPageWidth - 2*pdfMargin - indent
+	for _, paragraph := range strings.Split(text, "\n") {
+		for _, line := range wrap(paragraph, style.size, bold, width) {
+			p.reserve(style.leading)
+			p.line(line, style, bold, indent)
+		}
+	}
+}
+
+func (p *pdfDocument) line(text string, style pdfStyle, bold bool, indent float64) {
+	p.y -= style.leading
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.pages[len(p.pages)-1], "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n", font, style.size, pdfMargin+indent, p.y, pdfString(text))
+}
+
+// bytes assembles the finished PDF: catalog, page tree and fonts, then each
+// page with its content stream, and the cross-reference table
+func (p *pdfDocument) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range p.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// wrap breaks text into lines no wider than width, splitting words that do
+// not fit on a line of their own
+func wrap(text string, size float64, bold bool, width float64) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(candidate, size, bold) <= width {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = word
+		for textWidth(line, size, bold) > width {
+			cut := len([]rune(line)) * int(width) / int(textWidth(line, size, bold)+1)
+			if cut < 1 {
+				cut = 1
+			}
+			lines = append(lines, string([]rune(line)[:cut]))
+			line = string([]rune(line)[cut:])
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// textWidth estimates the printed width of text. Helvetica averages a little
+// over half an em per character; narrow and wide letters are common enough
+// to be worth telling apart.
+func textWidth(text string, size float64, bold bool) float64 {
+	ems := 0.0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("iljtf.,;:'!|() ", r):
+			ems += 0.3
+		case strings.ContainsRune("mwMW@", r):
+			ems += 0.85
+		case r >= 'A' && r <= 'Z':
+			ems += 0.67
+		default:
+			ems += 0.56
+		}
+	}
+	if bold {
+		ems *= 1.06
+	}
+	return ems * size
+}
+
+// pdfColor turns a #rgb or #rrggbb color into PDF color operands
+func pdfColor(color string) (string, bool) {
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return "", false
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(value>>16&0xff)/255, float64(value>>8&0xff)/255, float64(value&0xff)/255), true
+}
+
+// winAnsi maps the characters Windows-1252 adds to Latin-1 onto their codes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// pdfString encodes text as the body of a PDF string literal
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		var c byte
+		switch code, ok := winAnsi[r]; {
+		case ok:
+			c = code
+		case r >= 0x20 && r < 0x7f || r >= 0xa0 && r <= 0xff:
+			c = byte(r)
+		default:
+			c = '?'
+		}
+
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"live-retro-server/internal/models"
+)
+
+// ReportTopItems is how many of each column's items a report lists
+const ReportTopItems = 5
+
+// Report is a printable summary of a retro: the top-voted items of each
+// column with their discussion, and the action items agreed on
+type Report struct {
+	*Document
+	Participants int
+	Duration     time.Duration // from the first contribution to the last
+}
+
+// NewReport summarises the board. Each column keeps its ReportTopItems
+// most-voted items, ties keeping board order.
+func NewReport(board *models.Board, now time.Time) *Report {
+	doc := NewDocument(board, now)
+	for i := range doc.Columns {
+		items := doc.Columns[i].Items
+		sort.SliceStable(items, func(a, b int) bool {
+			return items[a].Votes > items[b].Votes
+		})
+		if len(items) > ReportTopItems {
+			doc.Columns[i].Items = items[:ReportTopItems]
+		}
+	}
+
+	first, last := board.ActivitySpan()
+	return &Report{
+		Document:     doc,
+		Participants: board.ParticipantCount(),
+		Duration:     last.Sub(first),
+	}
+}
+
+// ReportFormat selects how a report is rendered
+type ReportFormat string
+
+const (
+	ReportHTML ReportFormat = "html"
+	ReportPDF  ReportFormat = "pdf"
+)
+
+// ParseReportFormat returns the report format with the given name; empty
+// selects HTML
+func ParseReportFormat(name string) (ReportFormat, error) {
+	switch format := ReportFormat(name); format {
+	case "":
+		return ReportHTML, nil
+	case ReportHTML, ReportPDF:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported report format %q, use html or pdf", name)
+}
+
+// ContentType returns the MIME type of the format
+func (f ReportFormat) ContentType() string {
+	if f == ReportPDF {
+		return "application/pdf"
+	}
+	return "text/html; charset=utf-8"
+}
+
+// FileName suggests a name for the rendered report
+func (r *Report) FileName(format ReportFormat) string {
+	return fmt.Sprintf("retro-report-%s-%s.%s", r.CreatedAt.Format("2006-01-02"), r.BoardID, format)
+}
+
+// Write renders the report to w in the given format
+func (r *Report) Write(w io.Writer, format ReportFormat) error {
+	if format == ReportPDF {
+		return r.WritePDF(w)
+	}
+	return r.WriteHTML(w)
+}
+
+// Summary describes when the retro ran, for how long and with how many people
+func (r *Report) Summary() string {
+	participants := "1 participant"
+	if r.Participants != 1 {
+		participants = fmt.Sprintf("%d participants", r.Participants)
+	}
+	return fmt.Sprintf("%s · %s · %s", r.CreatedAt.Format("2 January 2006"), formatDuration(r.Duration), participants)
+}
+
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// WriteHTML renders the report as a single HTML page with its styles inline,
+// so it can be saved, mailed or printed without the app
+func (r *Report) WriteHTML(w io.Writer) error {
+	return reportTemplate.Execute(w, r)
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"votes": votes,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Retro report – {{.CreatedAt.Format "2 January 2006"}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2937; max-width: 800px; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; }
+h1 { margin-bottom: 0; }
+.summary { color: #6b7280; margin-top: 0.25rem; }
+h2 { border-left: 6px solid #9ca3af; padding-left: 0.5rem; margin-top: 2rem; }
+ul { padding-left: 1.25rem; }
+li { margin: 0.25rem 0; }
+.content { white-space: pre-line; }
+.author, .votes, .empty { color: #6b7280; }
+.threads { list-style: none; padding-left: 1rem; font-size: 0.9em; }
+.threads li::before { content: "💬 "; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.35rem 0.5rem; border-bottom: 1px solid #e5e7eb; }
+@media print { body { margin: 0; } h2 { break-after: avoid; } }
+</style>
+</head>
+<body>
+<h1>Retro report</h1>
+<p class="summary">{{.Summary}}</p>
+{{range .Columns}}
+<h2{{with .Color}} style="border-left-color: {{.}}"{{end}}>{{.Title}}</h2>
+{{if .Items}}<ul>
+{{range .Items}}{{if .IsGroup}}<li><strong>{{.Title}}</strong><span class="votes">{{votes .Votes}}</span>
+<ul>{{range .Tiles}}{{template "tile" .}}{{end}}</ul></li>
+{{else}}{{template "tile" .}}{{end}}{{end}}</ul>
+{{else}}<p class="empty">No items</p>
+{{end}}{{end}}
+<h2>Action items</h2>
+{{if .ActionItems}}<table>
+<tr><th>Action</th><th>Owner</th><th>Due</th><th>Status</th></tr>
+{{range .ActionItems}}<tr><td>{{.Title}}</td><td>{{.Owner}}</td><td>{{.DueDate}}</td><td>{{.Status}}</td></tr>
+{{end}}</table>
+{{else}}<p class="empty">No action items</p>
+{{end}}</body>
+</html>
+{{define "tile"}}<li><span class="content">{{.Content}}</span>{{with .Author}} <span class="author">— {{.}}</span>{{end}}<span class="votes">{{votes .Votes}}</span>
+{{if .Threads}}<ul class="threads">{{range .Threads}}<li><span class="content">{{.Content}}</span>{{with .Author}} <span class="author">— {{.}}</span>{{end}}</li>{{end}}</ul>{{end}}</li>
+{{end}}`))
@@ -0,0 +1,257 @@
+package hub
+
+import (
+	"encoding/json"
+	"html"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+func (c *Client) handleCreateActionItem(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var createPayload models.CreateActionItemPayload
+	if err := json.Unmarshal(data, &createPayload); err != nil {
+		logger.Errorf("Error unmarshaling create action item payload: %v", err)
+		c.sendErrorMessage("Invalid action item data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateCreateActionItemPayload(&createPayload); err != nil {
+		logger.Errorf("Invalid create action item payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	// Sanitize input
+	createPayload.Title = models.SanitizeString(createPayload.Title)
+	createPayload.Owner = models.SanitizeString(createPayload.Owner)
+	if createPayload.Status == "" {
+		createPayload.Status = models.ActionOpen
+	}
+
+	var newItem *models.ActionItem
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:action:create"); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		newItem = &models.ActionItem{
+			ID:        uuid.New().String(),
+			Title:     createPayload.Title,
+			Owner:     createPayload.Owner,
+			DueDate:   createPayload.DueDate,
+			Status:    createPayload.Status,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		board.ActionItems = append(board.ActionItems, newItem)
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error creating action item: %v", err)
+		return
+	}
+
+	c.hub.recordTeam(board)
+	c.broadcastEvent(board, models.EventActionCreated, models.ActionItemEventPayload{
+		ActionItem: newItem,
+	})
+}
+
+func (c *Client) handlePromoteTile(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var promotePayload models.PromoteTilePayload
+	if err := json.Unmarshal(data, &promotePayload); err != nil {
+		logger.Errorf("Error unmarshaling promote tile payload: %v", err)
+		c.sendErrorMessage("Invalid action item data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidatePromoteTilePayload(&promotePayload); err != nil {
+		logger.Errorf("Invalid promote tile payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	// Sanitize input
+	promotePayload.Owner = models.SanitizeString(promotePayload.Owner)
+
+	var newItem *models.ActionItem
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:action:promote"); err != nil {
+			return err
+		}
+
+		_, tile := board.FindTile(promotePayload.TileID)
+		if tile == nil {
+			return errTileNotFound
+		}
+		if tile.IsHidden {
+			return errTileHidden
+		}
+
+		now := time.Now()
+		newItem = &models.ActionItem{
+			ID:           uuid.New().String(),
+			Title:        actionTitleFromTile(tile.Content),
+			Owner:        promotePayload.Owner,
+			DueDate:      promotePayload.DueDate,
+			Status:       models.ActionOpen,
+			SourceTileID: tile.ID,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		board.ActionItems = append(board.ActionItems, newItem)
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err == errTileNotFound {
+		c.sendErrorMessage("Tile not found")
+		return
+	}
+	if err == errTileHidden {
+		c.sendErrorMessage("Cannot promote a hidden tile")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error promoting tile %s: %v", promotePayload.TileID, err)
+		return
+	}
+
+	c.hub.recordTeam(board)
+	c.broadcastEvent(board, models.EventActionCreated, models.ActionItemEventPayload{
+		ActionItem: newItem,
+	})
+}
+
+// actionTitleFromTile shortens already sanitized tile content to fit an
+// action item title
+func actionTitleFromTile(content string) string {
+	title := html.UnescapeString(content)
+	if utf8.RuneCountInString(title) > models.MaxActionTitleLength {
+		title = string([]rune(title)[:models.MaxActionTitleLength-1]) + "…"
+	}
+	return html.EscapeString(title)
+}
+
+func (c *Client) handleUpdateActionItem(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var updatePayload models.UpdateActionItemPayload
+	if err := json.Unmarshal(data, &updatePayload); err != nil {
+		logger.Errorf("Error unmarshaling update action item payload: %v", err)
+		c.sendErrorMessage("Invalid action item data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateUpdateActionItemPayload(&updatePayload); err != nil {
+		logger.Errorf("Invalid update action item payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	var updated *models.ActionItem
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		_, item := board.FindActionItem(updatePayload.ActionID)
+		if item == nil {
+			return errActionNotFound
+		}
+
+		if updatePayload.Title != nil {
+			item.Title = models.SanitizeString(*updatePayload.Title)
+		}
+		if updatePayload.Owner != nil {
+			item.Owner = models.SanitizeString(*updatePayload.Owner)
+		}
+		if updatePayload.DueDate != nil {
+			item.DueDate = *updatePayload.DueDate
+		}
+		if updatePayload.Status != nil {
+			item.Status = *updatePayload.Status
+		}
+		item.UpdatedAt = time.Now()
+		updated = item
+		return nil
+	})
+	if err == errActionNotFound {
+		c.sendErrorMessage("Action item not found")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error updating action item %s: %v", updatePayload.ActionID, err)
+		return
+	}
+
+	c.hub.recordTeam(board)
+	c.broadcastEvent(board, models.EventActionUpdated, models.ActionItemEventPayload{
+		ActionItem: updated,
+	})
+}
+
+func (c *Client) handleDeleteActionItem(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var deletePayload models.DeleteActionItemPayload
+	if err := json.Unmarshal(data, &deletePayload); err != nil {
+		logger.Errorf("Error unmarshaling delete action item payload: %v", err)
+		c.sendErrorMessage("Invalid action item data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateDeleteActionItemPayload(&deletePayload); err != nil {
+		logger.Errorf("Invalid delete action item payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		i, item := board.FindActionItem(deletePayload.ActionID)
+		if item == nil {
+			return errActionNotFound
+		}
+		board.ActionItems = append(board.ActionItems[:i], board.ActionItems[i+1:]...)
+		return nil
+	})
+	if err == errActionNotFound {
+		c.sendErrorMessage("Action item not found")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error deleting action item %s: %v", deletePayload.ActionID, err)
+		return
+	}
+
+	c.hub.recordTeam(board)
+	c.broadcastEvent(board, models.EventActionDeleted, models.ActionItemDeletedEventPayload{
+		ActionID: deletePayload.ActionID,
+	})
+}
+
+// recordTeam keeps the team's carried-over action items in step with its
+// latest board
+func (h *Hub) recordTeam(board *models.Board) {
+	if board.TeamID == "" {
+		return
+	}
+
+	// Once the team has moved on to a newer retro, older boards no longer count
+	if team, err := h.store.GetTeam(board.TeamID); err == nil && team.LastBoardID != board.ID {
+		return
+	}
+
+	if err := h.store.SaveTeam(board.TeamSnapshot()); err != nil {
+		logger.Errorf("Error saving team %s: %v", board.TeamID, err)
+	}
+}
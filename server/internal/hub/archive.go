@@ -0,0 +1,85 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+	"live-retro-server/internal/store"
+)
+
+// archiveSweepInterval is how often boards close to expiry are archived. A
+// board is archived again on each sweep while it changes, so the archive ends
+// up holding its state from just before it expired.
+const archiveSweepInterval = time.Minute
+
+// archiveExpiringBoards archives the boards that will expire before the next
+// sweep is sure to have run
+func (h *Hub) archiveExpiringBoards() {
+	boardIDs, err := h.store.ExpiringBoards(time.Now().Add(2 * archiveSweepInterval))
+	if err != nil {
+		logger.Errorf("Error listing expiring boards: %v", err)
+		return
+	}
+
+	for _, boardID := range boardIDs {
+		board, err := h.store.GetBoard(boardID)
+		if err != nil {
+			continue // expired in the meantime
+		}
+
+		// Unchanged since an earlier sweep, possibly by another replica sharing
+		// the archive, archived it
+		if archived, err := h.archive.Get(boardID); err == nil && archived.Board.Version >= board.Version {
+			continue
+		}
+
+		if err := h.archiveBoard(board, models.ArchiveExpired); err != nil {
+			logger.Errorf("Error archiving board %s: %v", boardID, err)
+		}
+	}
+}
+
+func (h *Hub) archiveBoard(board *models.Board, reason models.ArchiveReason) error {
+	return h.archive.Save(&models.ArchivedBoard{
+		Board:      board,
+		ArchivedAt: time.Now(),
+		Reason:     reason,
+	})
+}
+
+// errArchiveFailed keeps a board open when it could not be archived
+var errArchiveFailed = errors.New("failed to archive board")
+
+// handleCloseBoard ends the retro: the board is archived if archiving is
+// enabled, then deleted, and every client is told and disconnected. The
+// archive holds the very version deleted, so no last change is lost.
+func (c *Client) handleCloseBoard() {
+	archived := c.hub.archive != nil
+	_, err := store.DeleteBoard(context.Background(), c.hub.store, c.boardID, func(board *models.Board) error {
+		if !archived {
+			return nil
+		}
+		if err := c.hub.archiveBoard(board, models.ArchiveClosed); err != nil {
+			logger.Errorf("Error archiving board %s: %v", c.boardID, err)
+			return errArchiveFailed
+		}
+		return nil
+	})
+	if err == errArchiveFailed {
+		c.sendErrorMessage("Failed to archive the board; it was left open")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error closing board %s: %v", c.boardID, err)
+		c.sendErrorMessage("Failed to close the board")
+		return
+	}
+
+	c.publish(models.WebSocketMessage{
+		Type:    models.EventBoardClosed,
+		Payload: models.BoardClosedEventPayload{Archived: archived},
+	})
+}
@@ -0,0 +1,97 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+// brokerTimeout bounds each publish/subscribe round trip to the broker
+const brokerTimeout = 5 * time.Second
+
+// brokerEnvelope wraps an event published to other server instances. Origin
+// lets an instance skip events it already delivered to its own clients.
+type brokerEnvelope struct {
+	Origin      string          `json:"origin"`
+	Type        string          `json:"type"`
+	Seq         int64           `json:"seq,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+	VotesHidden bool            `json:"votesHidden,omitempty"`
+}
+
+// publishEvent sends an event to the other instances serving this board
+func (h *Hub) publishEvent(boardID string, event models.WebSocketMessage) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		logger.Errorf("Error marshaling %s event for publishing: %v", event.Type, err)
+		return
+	}
+
+	data, err := json.Marshal(brokerEnvelope{
+		Origin:      h.instanceID,
+		Type:        event.Type,
+		Seq:         event.Seq,
+		Payload:     payload,
+		VotesHidden: event.VotesHidden,
+	})
+	if err != nil {
+		logger.Errorf("Error marshaling %s event for publishing: %v", event.Type, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
+	if err := h.broker.Publish(ctx, boardID, data); err != nil {
+		logger.Errorf("Error publishing %s event for board %s: %v", event.Type, boardID, err)
+	}
+}
+
+// consumeBroker delivers events published by other instances to local clients
+func (h *Hub) consumeBroker() {
+	for msg := range h.broker.Messages() {
+		var envelope brokerEnvelope
+		if err := json.Unmarshal(msg.Data, &envelope); err != nil {
+			logger.Errorf("Error unmarshaling broker message for board %s: %v", msg.BoardID, err)
+			continue
+		}
+
+		if envelope.Origin == h.instanceID {
+			continue
+		}
+
+		payload, err := models.DecodeEventPayload(envelope.Type, envelope.Payload)
+		if err != nil {
+			logger.Errorf("Error decoding %s event for board %s: %v", envelope.Type, msg.BoardID, err)
+			continue
+		}
+
+		h.deliverEvent(msg.BoardID, models.WebSocketMessage{
+			Type:        envelope.Type,
+			Seq:         envelope.Seq,
+			Payload:     payload,
+			VotesHidden: envelope.VotesHidden,
+		})
+	}
+}
+
+func (h *Hub) subscribeBoard(boardID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
+	if err := h.broker.Subscribe(ctx, boardID); err != nil {
+		logger.Errorf("Error subscribing to board %s: %v", boardID, err)
+	}
+}
+
+func (h *Hub) unsubscribeBoard(boardID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), brokerTimeout)
+	defer cancel()
+
+	if err := h.broker.Unsubscribe(ctx, boardID); err != nil {
+		logger.Errorf("Error unsubscribing from board %s: %v", boardID, err)
+	}
+}
@@ -2,6 +2,8 @@ package hub
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -18,9 +20,50 @@ const (
 	maxMessageSize = 512
 )
 
+// Errors returned from board mutation closures to abort an update
+var (
+	errColumnNotFound     = errors.New("column not found")
+	errTileNotFound       = errors.New("tile not found")
+	errTileHidden         = errors.New("tile is hidden")
+	errNotPermitted       = errors.New("not permitted")
+	errInvalidColumnOrder = errors.New("invalid column order")
+	errNoChange           = errors.New("no change")
+	errGroupNotFound      = errors.New("group not found")
+	errTileGrouped        = errors.New("tile already grouped")
+	errSelfVote           = errors.New("self-voting not allowed")
+	errTileVoteLimit      = errors.New("per-tile vote limit reached")
+	errVoteBudgetSpent    = errors.New("no votes remaining")
+	errNoVoteToRemove     = errors.New("no vote to remove")
+	errActionNotFound     = errors.New("action item not found")
+	errPhaseDisabled      = errors.New("phase not enabled")
+	errRetentionLimit     = errors.New("beyond retention limit")
+	errColumnLimit        = errors.New("column limit reached")
+)
+
+// adminOnly lists the client messages only the board's admin may send
+var adminOnly = map[string]bool{
+	"client:tile:move":             true,
+	"client:tile:reveal":           true,
+	"client:board:reveal_all":      true,
+	"client:phase:advance":         true,
+	"client:timer:start":           true,
+	"client:timer:pause":           true,
+	"client:timer:extend":          true,
+	"client:timer:cancel":          true,
+	"client:board:voting_settings": true,
+	"client:board:extend":          true,
+	"client:board:keep_until":      true,
+	"client:board:close":           true,
+	"client:board:save_template":   true,
+	"client:column:create":         true,
+	"client:column:update":         true,
+	"client:column:reorder":        true,
+	"client:column:delete":         true,
+}
+
 func (c *Client) readPump() {
 	defer func() {
-		c.hub.unregister <- c
+		c.room.unregister <- c
 		c.conn.Close()
 		monitoring.DecrementConnections()
 		logger.Debugf("WebSocket connection closed: board=%s, user=%s", c.boardID, c.userID)
@@ -52,7 +95,14 @@ func (c *Client) readPump() {
 		}
 
 		logger.Debugf("Received message: type=%s, board=%s, user=%s", wsMsg.Type, c.boardID, c.userID)
-		c.handleMessage(wsMsg)
+
+		// Messages are handled on the board's goroutine, one at a time
+		c.room.submit(func() {
+			// The room may have dropped the client since the message was read
+			if !c.dropped {
+				c.handleMessage(wsMsg)
+			}
+		})
 	}
 }
 
@@ -104,37 +154,95 @@ func (c *Client) writePump() {
 }
 
 func (c *Client) handleMessage(msg models.WebSocketMessage) {
+	// Read-only requests are allowed for every role
+	switch msg.Type {
+	case "client:sync:request":
+		c.sendBoardState()
+		return
+	case "client:sync:resume":
+		c.handleSyncResume(msg.Payload)
+		return
+	}
+
+	if !c.role.CanEdit() {
+		c.sendErrorMessage("Observers cannot modify the board")
+		return
+	}
+
+	// Admin requests from anyone else are ignored
+	if adminOnly[msg.Type] && !c.isAdmin() {
+		return
+	}
+
 	switch msg.Type {
 	case "client:tile:create":
 		c.handleCreateTile(msg.Payload)
+	case "client:tile:update":
+		c.handleUpdateTile(msg.Payload)
+	case "client:tile:delete":
+		c.handleDeleteTile(msg.Payload)
+	case "client:tile:move":
+		c.handleMoveTile(msg.Payload)
 	case "client:tile:reveal":
-		if c.isAdmin {
-			c.handleRevealTile(msg.Payload)
-		}
+		c.handleRevealTile(msg.Payload)
 	case "client:board:reveal_all":
-		if c.isAdmin {
-			c.handleRevealAll(msg.Payload)
-		}
+		c.handleRevealAll(msg.Payload)
 	case "client:tile:vote":
 		c.handleVoteTile(msg.Payload)
+	case "client:phase:advance":
+		c.handleAdvancePhase(msg.Payload)
+	case "client:timer:start":
+		c.handleStartTimer(msg.Payload)
+	case "client:timer:pause":
+		c.handlePauseTimer(msg.Payload)
+	case "client:timer:extend":
+		c.handleExtendTimer(msg.Payload)
+	case "client:timer:cancel":
+		c.handleCancelTimer(msg.Payload)
+	case "client:board:voting_settings":
+		c.handleUpdateVotingSettings(msg.Payload)
+	case "client:board:extend":
+		c.handleExtendBoard(msg.Payload)
+	case "client:board:keep_until":
+		c.handleKeepBoardUntil(msg.Payload)
+	case "client:board:close":
+		c.handleCloseBoard()
+	case "client:board:save_template":
+		c.handleSaveBoardTemplate(msg.Payload)
 	case "client:column:create":
-		if c.isAdmin {
-			c.handleCreateColumn(msg.Payload)
-		}
+		c.handleCreateColumn(msg.Payload)
 	case "client:column:update":
-		if c.isAdmin {
-			c.handleUpdateColumn(msg.Payload)
-		}
+		c.handleUpdateColumn(msg.Payload)
+	case "client:column:reorder":
+		c.handleReorderColumns(msg.Payload)
 	case "client:column:delete":
-		if c.isAdmin {
-			c.handleDeleteColumn(msg.Payload)
-		}
+		c.handleDeleteColumn(msg.Payload)
 	case "client:user:typing_start":
 		c.handleTypingStart(msg.Payload)
 	case "client:user:typing_stop":
 		c.handleTypingStop(msg.Payload)
 	case "client:thread:create":
 		c.handleCreateThread(msg.Payload)
+	case "client:group:create":
+		c.handleCreateGroup(msg.Payload)
+	case "client:group:rename":
+		c.handleRenameGroup(msg.Payload)
+	case "client:group:add_tile":
+		c.handleAddGroupTile(msg.Payload)
+	case "client:group:remove_tile":
+		c.handleRemoveGroupTile(msg.Payload)
+	case "client:group:dissolve":
+		c.handleDissolveGroup(msg.Payload)
+	case "client:group:vote":
+		c.handleVoteGroup(msg.Payload)
+	case "client:action:create":
+		c.handleCreateActionItem(msg.Payload)
+	case "client:action:promote":
+		c.handlePromoteTile(msg.Payload)
+	case "client:action:update":
+		c.handleUpdateActionItem(msg.Payload)
+	case "client:action:delete":
+		c.handleDeleteActionItem(msg.Payload)
 	}
 }
 
@@ -158,96 +266,254 @@ func (c *Client) handleCreateTile(payload interface{}) {
 	createPayload.Content = models.SanitizeString(createPayload.Content)
 	createPayload.Author = models.SanitizeString(createPayload.Author)
 
-	board, err := c.hub.store.GetBoard(c.boardID)
-	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
-		return
-	}
-
-	column, exists := board.Columns[createPayload.ColumnID]
-	if !exists {
-		logger.Errorf("Column %s not found", createPayload.ColumnID)
-		return
-	}
-
 	newTile := &models.Tile{
 		ID:        uuid.New().String(),
 		Content:   createPayload.Content,
 		Author:    createPayload.Author,
+		AuthorID:  c.userID,
 		IsHidden:  true,
 		VoterIDs:  []string{},
 		Threads:   []*models.Thread{},
 		CreatedAt: time.Now(),
 	}
 
-	column.Tiles = append(column.Tiles, newTile)
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:tile:create"); err != nil {
+			return err
+		}
+
+		column, exists := board.Columns[createPayload.ColumnID]
+		if !exists {
+			return errColumnNotFound
+		}
 
-	if err := c.hub.store.SaveBoard(board); err != nil {
-		logger.Errorf("Error saving board: %v", err)
+		column.Tiles = append(column.Tiles, newTile)
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error creating tile in column %s: %v", createPayload.ColumnID, err)
 		return
 	}
 
-	c.broadcastBoardState()
+	c.broadcastEvent(board, models.EventTileCreated, models.TileEventPayload{
+		ColumnID: createPayload.ColumnID,
+		Tile:     newTile,
+	})
 }
 
-func (c *Client) handleRevealTile(payload interface{}) {
+// canModifyTile reports whether the client may edit or delete the tile
+func (c *Client) canModifyTile(tile *models.Tile) bool {
+	return c.isAdmin() || (tile.AuthorID != "" && tile.AuthorID == c.userID)
+}
+
+func (c *Client) handleUpdateTile(payload interface{}) {
 	data, _ := json.Marshal(payload)
-	var revealPayload models.RevealTilePayload
-	if err := json.Unmarshal(data, &revealPayload); err != nil {
-		logger.Errorf("Error unmarshaling reveal tile payload: %v", err)
+	var updatePayload models.UpdateTilePayload
+	if err := json.Unmarshal(data, &updatePayload); err != nil {
+		logger.Errorf("Error unmarshaling update tile payload: %v", err)
+		c.sendErrorMessage("Invalid tile update data")
 		return
 	}
 
-	board, err := c.hub.store.GetBoard(c.boardID)
+	// Validate payload
+	if err := models.ValidateUpdateTilePayload(&updatePayload); err != nil {
+		logger.Errorf("Invalid update tile payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	// Sanitize input
+	updatePayload.Content = models.SanitizeString(updatePayload.Content)
+
+	var updated models.TileEventPayload
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:tile:update"); err != nil {
+			return err
+		}
+
+		column, tile := board.FindTile(updatePayload.TileID)
+		if tile == nil {
+			return errTileNotFound
+		}
+		if !c.canModifyTile(tile) {
+			return errNotPermitted
+		}
+		tile.Content = updatePayload.Content
+		updated = models.TileEventPayload{ColumnID: column.ID, Tile: tile}
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err == errTileNotFound {
+		c.sendErrorMessage("Tile not found")
+		return
+	}
+	if err == errNotPermitted {
+		c.sendErrorMessage("Only the tile's author or an admin can edit it")
+		return
+	}
 	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
+		logger.Errorf("Error updating tile %s: %v", updatePayload.TileID, err)
 		return
 	}
 
-	// Find and reveal the tile
-	for _, column := range board.Columns {
-		for _, tile := range column.Tiles {
-			if tile.ID == revealPayload.TileID {
-				tile.IsHidden = false
-				if err := c.hub.store.SaveBoard(board); err != nil {
-					logger.Errorf("Error saving board: %v", err)
-					return
-				}
-				c.broadcastBoardState()
-				return
-			}
+	c.broadcastEvent(board, models.EventTileUpdated, updated)
+}
+
+func (c *Client) handleDeleteTile(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var deletePayload models.DeleteTilePayload
+	if err := json.Unmarshal(data, &deletePayload); err != nil {
+		logger.Errorf("Error unmarshaling delete tile payload: %v", err)
+		c.sendErrorMessage("Invalid tile delete data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateDeleteTilePayload(&deletePayload); err != nil {
+		logger.Errorf("Invalid delete tile payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	var deleted models.TileDeletedEventPayload
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:tile:delete"); err != nil {
+			return err
+		}
+
+		_, tile := board.FindTile(deletePayload.TileID)
+		if tile == nil {
+			return errTileNotFound
+		}
+		if !c.canModifyTile(tile) {
+			return errNotPermitted
 		}
+		column := board.RemoveTile(tile.ID)
+		deleted = models.TileDeletedEventPayload{ColumnID: column.ID, TileID: tile.ID}
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err == errTileNotFound {
+		c.sendErrorMessage("Tile not found")
+		return
 	}
+	if err == errNotPermitted {
+		c.sendErrorMessage("Only the tile's author or an admin can delete it")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error deleting tile %s: %v", deletePayload.TileID, err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventTileDeleted, deleted)
 }
 
-func (c *Client) handleRevealAll(payload interface{}) {
-	board, err := c.hub.store.GetBoard(c.boardID)
+func (c *Client) handleMoveTile(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var movePayload models.MoveTilePayload
+	if err := json.Unmarshal(data, &movePayload); err != nil {
+		logger.Errorf("Error unmarshaling move tile payload: %v", err)
+		c.sendErrorMessage("Invalid tile move data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateMoveTilePayload(&movePayload); err != nil {
+		logger.Errorf("Invalid move tile payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	var moved models.TileMovedEventPayload
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		source, index, ok := board.MoveTile(movePayload.TileID, movePayload.ColumnID, movePayload.Index)
+		if !ok {
+			return errTileNotFound
+		}
+		moved = models.TileMovedEventPayload{
+			TileID:       movePayload.TileID,
+			FromColumnID: source.ID,
+			ToColumnID:   movePayload.ColumnID,
+			Index:        index,
+		}
+		return nil
+	})
+	if err == errTileNotFound {
+		c.sendErrorMessage("Tile or column not found")
+		return
+	}
 	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
+		logger.Errorf("Error moving tile %s: %v", movePayload.TileID, err)
 		return
 	}
 
-	// Reveal all hidden tiles across all columns
-	tilesRevealed := 0
-	for _, column := range board.Columns {
-		for _, tile := range column.Tiles {
-			if tile.IsHidden {
-				tile.IsHidden = false
-				tilesRevealed++
-			}
+	c.broadcastEvent(board, models.EventTileMoved, moved)
+}
+
+func (c *Client) handleRevealTile(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var revealPayload models.RevealTilePayload
+	if err := json.Unmarshal(data, &revealPayload); err != nil {
+		logger.Errorf("Error unmarshaling reveal tile payload: %v", err)
+		return
+	}
+
+	var revealed models.TileEventPayload
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		column, tile := board.FindTile(revealPayload.TileID)
+		if tile == nil {
+			return errTileNotFound
 		}
+		tile.IsHidden = false
+		revealed = models.TileEventPayload{ColumnID: column.ID, Tile: tile}
+		return nil
+	})
+	if err != nil {
+		logger.Errorf("Error revealing tile %s: %v", revealPayload.TileID, err)
+		return
 	}
 
-	if tilesRevealed > 0 {
-		if err := c.hub.store.SaveBoard(board); err != nil {
-			logger.Errorf("Error saving board: %v", err)
-			return
+	c.broadcastEvent(board, models.EventTileRevealed, revealed)
+}
+
+func (c *Client) handleRevealAll(payload interface{}) {
+	// Reveal all hidden tiles across all columns
+	var revealed []models.TileEventPayload
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		revealed = nil
+		for _, column := range board.Columns {
+			for _, tile := range column.Tiles {
+				if tile.IsHidden {
+					tile.IsHidden = false
+					revealed = append(revealed, models.TileEventPayload{ColumnID: column.ID, Tile: tile})
+				}
+			}
 		}
-		logger.Infof("Admin revealed %d tiles on board %s", tilesRevealed, c.boardID)
-		c.broadcastBoardState()
-	} else {
+		if len(revealed) == 0 {
+			return errNoChange
+		}
+		return nil
+	})
+
+	if err == errNoChange {
 		logger.Debugf("No hidden tiles to reveal on board %s", c.boardID)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error revealing tiles: %v", err)
+		return
 	}
+
+	logger.Infof("Admin revealed %d tiles on board %s", len(revealed), c.boardID)
+	c.broadcastEvent(board, models.EventTilesRevealed, models.TilesEventPayload{Tiles: revealed})
 }
 
 func (c *Client) handleVoteTile(payload interface{}) {
@@ -258,41 +524,52 @@ func (c *Client) handleVoteTile(payload interface{}) {
 		return
 	}
 
-	board, err := c.hub.store.GetBoard(c.boardID)
-	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
-		return
-	}
-
-	// Find the tile and toggle vote
-	for _, column := range board.Columns {
-		for _, tile := range column.Tiles {
-			if tile.ID == votePayload.TileID {
-				// Check if user already voted
-				voted := false
-				for i, voterID := range tile.VoterIDs {
-					if voterID == c.userID {
-						// Remove vote
-						tile.VoterIDs = append(tile.VoterIDs[:i], tile.VoterIDs[i+1:]...)
-						voted = true
-						break
-					}
-				}
-				
-				if !voted {
-					// Add vote
-					tile.VoterIDs = append(tile.VoterIDs, c.userID)
-				}
+	var changed models.VoteEventPayload
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:tile:vote"); err != nil {
+			return err
+		}
 
-				if err := c.hub.store.SaveBoard(board); err != nil {
-					logger.Errorf("Error saving board: %v", err)
-					return
-				}
-				c.broadcastBoardState()
-				return
-			}
+		column, tile := board.FindTile(votePayload.TileID)
+		if tile == nil {
+			return errTileNotFound
+		}
+
+		voterIDs, err := c.castVote(board, tile.VoterIDs, tile.AuthorID == c.userID, votePayload.Remove)
+		if err != nil {
+			return err
+		}
+		tile.VoterIDs = voterIDs
+		votedAt := time.Now()
+		board.LastVoteAt = &votedAt
+
+		changed = models.VoteEventPayload{
+			TileID:   tile.ID,
+			VoterIDs: tile.VoterIDs,
 		}
+
+		// A grouped tile's vote also counts towards its group
+		column.RefreshGroupVotes()
+		if group := column.GroupOf(tile.ID); group != nil {
+			changed.GroupID = group.ID
+			changed.GroupVotes = group.TotalVotes
+		}
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
 	}
+	if message, ok := voteErrorMessage(err); ok {
+		c.sendErrorMessage(message)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error voting on tile %s: %v", votePayload.TileID, err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventVoteChanged, changed)
+	c.sendVotesRemaining(board)
 }
 
 func (c *Client) handleCreateColumn(payload interface{}) {
@@ -314,27 +591,39 @@ func (c *Client) handleCreateColumn(payload interface{}) {
 	// Sanitize input
 	createPayload.Title = models.SanitizeString(createPayload.Title)
 
-	board, err := c.hub.store.GetBoard(c.boardID)
-	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
-		return
-	}
+	var newColumn *models.Column
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if len(board.Columns) >= c.hub.maxColumns {
+			return errColumnLimit
+		}
 
-	newColumn := &models.Column{
-		ID:    uuid.New().String(),
-		Title: createPayload.Title,
-		Order: len(board.Columns),
-		Tiles: []*models.Tile{},
+		// Renumber first so the new column lands after every existing one
+		board.NormalizeColumnOrder()
+		newColumn = &models.Column{
+			ID:     uuid.New().String(),
+			Title:  createPayload.Title,
+			Order:  len(board.Columns),
+			Tiles:  []*models.Tile{},
+			Groups: []*models.Group{},
+		}
+		board.Columns[newColumn.ID] = newColumn
+		return nil
+	})
+	if err == errColumnLimit {
+		c.sendErrorMessage(fmt.Sprintf("A board may have at most %d columns", c.hub.maxColumns))
+		return
 	}
-
-	board.Columns[newColumn.ID] = newColumn
-
-	if err := c.hub.store.SaveBoard(board); err != nil {
-		logger.Errorf("Error saving board: %v", err)
+	if err != nil {
+		logger.Errorf("Error creating column: %v", err)
 		return
 	}
 
-	c.broadcastBoardState()
+	c.broadcastEvent(board, models.EventColumnCreated, models.ColumnEventPayload{
+		ColumnID:    newColumn.ID,
+		Title:       newColumn.Title,
+		Order:       newColumn.Order,
+		ColumnOrder: board.ColumnIDs(),
+	})
 }
 
 func (c *Client) handleUpdateColumn(payload interface{}) {
@@ -356,25 +645,33 @@ func (c *Client) handleUpdateColumn(payload interface{}) {
 	// Sanitize input
 	updatePayload.Title = models.SanitizeString(updatePayload.Title)
 
-	board, err := c.hub.store.GetBoard(c.boardID)
-	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
-		return
-	}
-
-	if column, exists := board.Columns[updatePayload.ColumnID]; exists {
+	var updated models.ColumnEventPayload
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		column, exists := board.Columns[updatePayload.ColumnID]
+		if !exists {
+			return errColumnNotFound
+		}
 		column.Title = updatePayload.Title
-
-		if err := c.hub.store.SaveBoard(board); err != nil {
-			logger.Errorf("Error saving board: %v", err)
-			return
+		board.NormalizeColumnOrder()
+		updated = models.ColumnEventPayload{
+			ColumnID:    column.ID,
+			Title:       column.Title,
+			Order:       column.Order,
+			ColumnOrder: board.ColumnIDs(),
 		}
-
-		c.broadcastBoardState()
-	} else {
+		return nil
+	})
+	if err == errColumnNotFound {
 		logger.Errorf("Column %s not found for update", updatePayload.ColumnID)
 		c.sendErrorMessage("Column not found")
+		return
 	}
+	if err != nil {
+		logger.Errorf("Error updating column: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventColumnUpdated, updated)
 }
 
 func (c *Client) handleDeleteColumn(payload interface{}) {
@@ -393,25 +690,64 @@ func (c *Client) handleDeleteColumn(payload interface{}) {
 		return
 	}
 
-	board, err := c.hub.store.GetBoard(c.boardID)
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if _, exists := board.Columns[deletePayload.ColumnID]; !exists {
+			return errColumnNotFound
+		}
+		delete(board.Columns, deletePayload.ColumnID)
+		board.NormalizeColumnOrder()
+		return nil
+	})
+	if err == errColumnNotFound {
+		logger.Errorf("Column %s not found for deletion", deletePayload.ColumnID)
+		c.sendErrorMessage("Column not found")
+		return
+	}
 	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
+		logger.Errorf("Error deleting column: %v", err)
 		return
 	}
 
-	if _, exists := board.Columns[deletePayload.ColumnID]; exists {
-		delete(board.Columns, deletePayload.ColumnID)
+	c.broadcastEvent(board, models.EventColumnDeleted, models.ColumnDeletedEventPayload{
+		ColumnID:    deletePayload.ColumnID,
+		ColumnOrder: board.ColumnIDs(),
+	})
+}
 
-		if err := c.hub.store.SaveBoard(board); err != nil {
-			logger.Errorf("Error saving board: %v", err)
-			return
-		}
+func (c *Client) handleReorderColumns(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var reorderPayload models.ReorderColumnsPayload
+	if err := json.Unmarshal(data, &reorderPayload); err != nil {
+		logger.Errorf("Error unmarshaling reorder columns payload: %v", err)
+		c.sendErrorMessage("Invalid column order data")
+		return
+	}
 
-		c.broadcastBoardState()
-	} else {
-		logger.Errorf("Column %s not found for deletion", deletePayload.ColumnID)
-		c.sendErrorMessage("Column not found")
+	// Validate payload
+	if err := models.ValidateReorderColumnsPayload(&reorderPayload); err != nil {
+		logger.Errorf("Invalid reorder columns payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
 	}
+
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if !board.ReorderColumns(reorderPayload.ColumnIDs) {
+			return errInvalidColumnOrder
+		}
+		return nil
+	})
+	if err == errInvalidColumnOrder {
+		c.sendErrorMessage("Column order must list every column exactly once")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error reordering columns: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventColumnsReordered, models.ColumnsReorderedEventPayload{
+		ColumnOrder: board.ColumnIDs(),
+	})
 }
 
 func (c *Client) handleTypingStart(payload interface{}) {
@@ -424,8 +760,7 @@ func (c *Client) handleTypingStart(payload interface{}) {
 		},
 	}
 
-	data, _ := json.Marshal(typingMsg)
-	c.hub.BroadcastToBoard(c.boardID, data)
+	c.publish(typingMsg)
 }
 
 func (c *Client) handleTypingStop(payload interface{}) {
@@ -438,8 +773,7 @@ func (c *Client) handleTypingStop(payload interface{}) {
 		},
 	}
 
-	data, _ := json.Marshal(typingMsg)
-	c.hub.BroadcastToBoard(c.boardID, data)
+	c.publish(typingMsg)
 }
 
 func (c *Client) handleCreateThread(payload interface{}) {
@@ -462,58 +796,147 @@ func (c *Client) handleCreateThread(payload interface{}) {
 	createPayload.Content = models.SanitizeString(createPayload.Content)
 	createPayload.Author = models.SanitizeString(createPayload.Author)
 
-	board, err := c.hub.store.GetBoard(c.boardID)
+	newThread := &models.Thread{
+		ID:        uuid.New().String(),
+		Content:   createPayload.Content,
+		Author:    createPayload.Author,
+		AuthorID:  c.userID,
+		CreatedAt: time.Now(),
+	}
+
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:thread:create"); err != nil {
+			return err
+		}
+
+		_, tile := board.FindTile(createPayload.TileID)
+		if tile == nil {
+			return errTileNotFound
+		}
+
+		tile.Threads = append(tile.Threads, newThread)
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
 	if err != nil {
-		logger.Errorf("Error getting board: %v", err)
+		logger.Errorf("Error creating thread on tile %s: %v", createPayload.TileID, err)
 		return
 	}
 
-	// Find the tile and add thread
-	for _, column := range board.Columns {
-		for _, tile := range column.Tiles {
-			if tile.ID == createPayload.TileID {
-				newThread := &models.Thread{
-					ID:        uuid.New().String(),
-					Content:   createPayload.Content,
-					Author:    createPayload.Author,
-					CreatedAt: time.Now(),
-				}
+	c.broadcastEvent(board, models.EventThreadCreated, models.ThreadEventPayload{
+		TileID: createPayload.TileID,
+		Thread: newThread,
+	})
+}
 
-				tile.Threads = append(tile.Threads, newThread)
+// broadcastEvent sends a change to the board, sequenced by the board version
+// the change produced
+func (c *Client) broadcastEvent(board *models.Board, eventType string, payload interface{}) {
+	c.publish(models.NewBoardEvent(board, eventType, payload))
+}
 
-				if err := c.hub.store.SaveBoard(board); err != nil {
-					logger.Errorf("Error saving board: %v", err)
-					return
-				}
-				c.broadcastBoardState()
-				return
-			}
+// publish sends an event to everyone on the board. A REST caller is not one
+// of the room's clients: it is sent its own copy, and without a room on this
+// instance the event goes straight to the event log and other instances.
+func (c *Client) publish(event models.WebSocketMessage) {
+	if c.room != nil {
+		c.room.broadcastEvent(event)
+		if c.room.clients[c] {
+			return
 		}
+	} else {
+		c.hub.deliverEvent(c.boardID, event)
+		c.hub.publishEvent(c.boardID, event)
+	}
+
+	data, err := json.Marshal(models.ProjectEvent(event, c.viewer()))
+	if err != nil {
+		logger.Errorf("Error marshaling %s event for client: %v", event.Type, err)
+		return
 	}
+	c.queue(data)
 }
 
-func (c *Client) broadcastBoardState() {
+// handleSyncResume replays the events a reconnecting client missed, or sends
+// a full snapshot when they have already fallen out of the event log
+func (c *Client) handleSyncResume(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var resumePayload models.SyncResumePayload
+	if err := json.Unmarshal(data, &resumePayload); err != nil {
+		logger.Errorf("Error unmarshaling sync resume payload: %v", err)
+		c.sendBoardState()
+		return
+	}
+
 	board, err := c.hub.store.GetBoard(c.boardID)
 	if err != nil {
-		logger.Errorf("Error getting board for broadcast: %v", err)
+		logger.Errorf("Error getting board %s: %v", c.boardID, err)
 		return
 	}
 
-	// Sanitize board data before broadcasting
-	models.SanitizeBoard(board)
+	missed, ok := c.hub.eventLogFor(c.boardID).since(resumePayload.LastSeq, board.Version)
+	if !ok {
+		logger.Debugf("Resume gap too old for board %s (last seq %d, current %d), sending snapshot", c.boardID, resumePayload.LastSeq, board.Version)
+		c.sendBoardState()
+		return
+	}
 
-	boardStateMsg := models.WebSocketMessage{
-		Type:    "server:board:state_update",
-		Payload: board,
+	// As for live clients, a phase change can reveal or hide votes that the
+	// deltas cannot express
+	for _, event := range missed {
+		if changesPhase(event) {
+			logger.Debugf("Resume on board %s crosses a phase change, sending snapshot", c.boardID)
+			c.sendBoard(board)
+			return
+		}
 	}
 
-	data, err := json.Marshal(boardStateMsg)
+	for _, event := range missed {
+		data, err := json.Marshal(models.ProjectEvent(event, c.viewer()))
+		if err != nil {
+			logger.Errorf("Error marshaling %s event for resume: %v", event.Type, err)
+			c.sendBoardState()
+			return
+		}
+
+		if !c.queue(data) {
+			logger.Errorf("Failed to replay events to client, sending snapshot")
+			c.sendBoardState()
+			return
+		}
+	}
+
+	c.sendVotesRemaining(board)
+	logger.Debugf("Resumed client on board %s with %d missed events", c.boardID, len(missed))
+}
+
+// sendBoardState sends this client a full snapshot of the board, used on
+// join and when the client asks to resync
+func (c *Client) sendBoardState() {
+	board, err := c.hub.store.GetBoard(c.boardID)
 	if err != nil {
-		logger.Errorf("Error marshaling board state for broadcast: %v", err)
+		logger.Errorf("Error getting board %s: %v", c.boardID, err)
 		return
 	}
 
-	c.hub.BroadcastToBoard(c.boardID, data)
+	c.sendBoard(board)
+}
+
+// sendBoard sends this client a snapshot of an already loaded board
+func (c *Client) sendBoard(board *models.Board) {
+	data, err := marshalBoardState(board, c.viewer())
+	if err != nil {
+		logger.Errorf("Error marshaling board state: %v", err)
+		return
+	}
+
+	if !c.queue(data) {
+		logger.Errorf("Failed to send board state to client")
+	}
+
+	c.sendVotesRemaining(board)
 }
 
 func (c *Client) sendErrorMessage(message string) {
@@ -530,9 +953,23 @@ func (c *Client) sendErrorMessage(message string) {
 		return
 	}
 
+	if !c.queue(data) {
+		logger.Errorf("Failed to send error message to client")
+	}
+}
+
+// queue sends data to the client without blocking. It reports false if the
+// client's buffer is full or the room has already dropped it. Must be called
+// where the client's messages are handled, once it has registered.
+func (c *Client) queue(data []byte) bool {
+	if c.dropped {
+		return false
+	}
+
 	select {
 	case c.send <- data:
+		return true
 	default:
-		logger.Errorf("Failed to send error message to client")
+		return false
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,74 @@
+package hub
+
+import (
+	"sort"
+	"sync"
+
+	"live-retro-server/internal/models"
+)
+
+// eventLogSize is how many recent events each board keeps for resuming clients
+const eventLogSize = 256
+
+// eventLog holds the most recent events of a board ordered by sequence number
+type eventLog struct {
+	mu     sync.Mutex
+	events []models.WebSocketMessage
+}
+
+func (l *eventLog) append(event models.WebSocketMessage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Concurrent mutations may finish out of order; keep the log sorted
+	i := sort.Search(len(l.events), func(i int) bool { return l.events[i].Seq >= event.Seq })
+	if i < len(l.events) && l.events[i].Seq == event.Seq {
+		return
+	}
+	l.events = append(l.events, models.WebSocketMessage{})
+	copy(l.events[i+1:], l.events[i:])
+	l.events[i] = event
+
+	if len(l.events) > eventLogSize {
+		l.events = append([]models.WebSocketMessage(nil), l.events[len(l.events)-eventLogSize:]...)
+	}
+}
+
+// since returns the events after lastSeq up to and including currentSeq. It
+// reports false if any of them are no longer (or not yet) in the log.
+func (l *eventLog) since(lastSeq, currentSeq int64) ([]models.WebSocketMessage, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	i := sort.Search(len(l.events), func(i int) bool { return l.events[i].Seq > lastSeq })
+
+	var missed []models.WebSocketMessage
+	expected := lastSeq + 1
+	for ; i < len(l.events) && l.events[i].Seq <= currentSeq; i++ {
+		if l.events[i].Seq != expected {
+			return nil, false
+		}
+		missed = append(missed, l.events[i])
+		expected++
+	}
+
+	return missed, expected == currentSeq+1
+}
+
+func (h *Hub) eventLogFor(boardID string) *eventLog {
+	h.eventLogsMu.Lock()
+	defer h.eventLogsMu.Unlock()
+
+	log, ok := h.eventLogs[boardID]
+	if !ok {
+		log = &eventLog{}
+		h.eventLogs[boardID] = log
+	}
+	return log
+}
+
+func (h *Hub) dropEventLog(boardID string) {
+	h.eventLogsMu.Lock()
+	delete(h.eventLogs, boardID)
+	h.eventLogsMu.Unlock()
+}
@@ -0,0 +1,63 @@
+package hub
+
+import (
+	"testing"
+
+	"live-retro-server/internal/models"
+)
+
+func TestEventLogSince(t *testing.T) {
+	tests := []struct {
+		name       string
+		appended   []int64
+		lastSeq    int64
+		currentSeq int64
+		want       []int64
+		wantOK     bool
+	}{
+		{name: "up to date", appended: []int64{1, 2, 3}, lastSeq: 3, currentSeq: 3, wantOK: true},
+		{name: "missed events", appended: []int64{1, 2, 3, 4}, lastSeq: 2, currentSeq: 4, want: []int64{3, 4}, wantOK: true},
+		{name: "stops at the current sequence", appended: []int64{1, 2, 3, 4}, lastSeq: 1, currentSeq: 3, want: []int64{2, 3}, wantOK: true},
+		{name: "appended out of order", appended: []int64{1, 3, 2, 4}, lastSeq: 1, currentSeq: 4, want: []int64{2, 3, 4}, wantOK: true},
+		{name: "duplicates ignored", appended: []int64{1, 2, 2, 3}, lastSeq: 0, currentSeq: 3, want: []int64{1, 2, 3}, wantOK: true},
+		{name: "gap in the log", appended: []int64{1, 2, 4}, lastSeq: 1, currentSeq: 4, wantOK: false},
+		{name: "not yet logged", appended: []int64{1, 2}, lastSeq: 1, currentSeq: 3, wantOK: false},
+		{name: "empty log", lastSeq: 0, currentSeq: 1, wantOK: false},
+		{name: "trimmed from the log", appended: sequence(1, eventLogSize+10), lastSeq: 5, currentSeq: eventLogSize + 10, wantOK: false},
+		{name: "oldest event kept", appended: sequence(1, eventLogSize+10), lastSeq: 10, currentSeq: 12, want: []int64{11, 12}, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log eventLog
+			for _, seq := range tt.appended {
+				log.append(models.WebSocketMessage{Seq: seq})
+			}
+
+			missed, ok := log.since(tt.lastSeq, tt.currentSeq)
+			if ok != tt.wantOK {
+				t.Fatalf("since(%d, %d) ok = %v, want %v", tt.lastSeq, tt.currentSeq, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if len(missed) != len(tt.want) {
+				t.Fatalf("since(%d, %d) returned %d events, want %d", tt.lastSeq, tt.currentSeq, len(missed), len(tt.want))
+			}
+			for i, event := range missed {
+				if event.Seq != tt.want[i] {
+					t.Errorf("event %d has seq %d, want %d", i, event.Seq, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// sequence returns the sequence numbers from first to last
+func sequence(first, last int64) []int64 {
+	seqs := make([]int64, 0, last-first+1)
+	for seq := first; seq <= last; seq++ {
+		seqs = append(seqs, seq)
+	}
+	return seqs
+}
@@ -0,0 +1,348 @@
+package hub
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+func (c *Client) handleCreateGroup(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var createPayload models.CreateGroupPayload
+	if err := json.Unmarshal(data, &createPayload); err != nil {
+		logger.Errorf("Error unmarshaling create group payload: %v", err)
+		c.sendErrorMessage("Invalid group data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateCreateGroupPayload(&createPayload); err != nil {
+		logger.Errorf("Invalid create group payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	// Sanitize input
+	createPayload.Title = models.SanitizeString(createPayload.Title)
+
+	var newGroup *models.Group
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:group:create"); err != nil {
+			return err
+		}
+
+		column, exists := board.Columns[createPayload.ColumnID]
+		if !exists {
+			return errColumnNotFound
+		}
+
+		newGroup = &models.Group{
+			ID:        uuid.New().String(),
+			Title:     createPayload.Title,
+			TileIDs:   []string{},
+			VoterIDs:  []string{},
+			CreatedAt: time.Now(),
+		}
+		for _, tileID := range createPayload.TileIDs {
+			if column.FindTile(tileID) == nil {
+				return errTileNotFound
+			}
+			if column.GroupOf(tileID) != nil || containsID(newGroup.TileIDs, tileID) {
+				return errTileGrouped
+			}
+			newGroup.TileIDs = append(newGroup.TileIDs, tileID)
+		}
+
+		column.Groups = append(column.Groups, newGroup)
+		column.RefreshGroupVotes()
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err == errColumnNotFound {
+		c.sendErrorMessage("Column not found")
+		return
+	}
+	if err == errTileNotFound {
+		c.sendErrorMessage("Grouped tiles must belong to the group's column")
+		return
+	}
+	if err == errTileGrouped {
+		c.sendErrorMessage("Tile is already in a group")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error creating group: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventGroupCreated, models.NewGroupEventPayload(board.Columns[createPayload.ColumnID], newGroup))
+}
+
+func (c *Client) handleRenameGroup(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var renamePayload models.RenameGroupPayload
+	if err := json.Unmarshal(data, &renamePayload); err != nil {
+		logger.Errorf("Error unmarshaling rename group payload: %v", err)
+		c.sendErrorMessage("Invalid group data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateRenameGroupPayload(&renamePayload); err != nil {
+		logger.Errorf("Invalid rename group payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	// Sanitize input
+	renamePayload.Title = models.SanitizeString(renamePayload.Title)
+
+	c.updateGroup("client:group:rename", renamePayload.GroupID, func(board *models.Board, column *models.Column, group *models.Group) error {
+		group.Title = renamePayload.Title
+		return nil
+	})
+}
+
+func (c *Client) handleAddGroupTile(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var tilePayload models.GroupTilePayload
+	if err := json.Unmarshal(data, &tilePayload); err != nil {
+		logger.Errorf("Error unmarshaling group tile payload: %v", err)
+		c.sendErrorMessage("Invalid group data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateGroupTilePayload(&tilePayload); err != nil {
+		logger.Errorf("Invalid group tile payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	c.updateGroup("client:group:add_tile", tilePayload.GroupID, func(board *models.Board, column *models.Column, group *models.Group) error {
+		if column.FindTile(tilePayload.TileID) == nil {
+			return errTileNotFound
+		}
+		if column.GroupOf(tilePayload.TileID) != nil {
+			return errTileGrouped
+		}
+		group.TileIDs = append(group.TileIDs, tilePayload.TileID)
+		column.RefreshGroupVotes()
+		return nil
+	})
+}
+
+func (c *Client) handleRemoveGroupTile(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var tilePayload models.GroupTilePayload
+	if err := json.Unmarshal(data, &tilePayload); err != nil {
+		logger.Errorf("Error unmarshaling group tile payload: %v", err)
+		c.sendErrorMessage("Invalid group data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateGroupTilePayload(&tilePayload); err != nil {
+		logger.Errorf("Invalid group tile payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	// Removing the last tile dissolves the group, which is a different event
+	var columnID string
+	var group *models.Group
+	dissolved := false
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:group:remove_tile"); err != nil {
+			return err
+		}
+
+		column, existing := board.FindGroup(tilePayload.GroupID)
+		if existing == nil {
+			return errGroupNotFound
+		}
+		if !containsID(existing.TileIDs, tilePayload.TileID) {
+			return errTileNotFound
+		}
+
+		column.UngroupTile(tilePayload.TileID)
+		columnID = column.ID
+		group = existing
+		dissolved = len(existing.TileIDs) == 0
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err == errGroupNotFound {
+		c.sendErrorMessage("Group not found")
+		return
+	}
+	if err == errTileNotFound {
+		c.sendErrorMessage("Tile is not in this group")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error removing tile from group %s: %v", tilePayload.GroupID, err)
+		return
+	}
+
+	if dissolved {
+		c.broadcastEvent(board, models.EventGroupDissolved, models.GroupDissolvedEventPayload{
+			ColumnID: columnID,
+			GroupID:  group.ID,
+		})
+		return
+	}
+	c.broadcastEvent(board, models.EventGroupUpdated, models.NewGroupEventPayload(board.Columns[columnID], group))
+}
+
+func (c *Client) handleDissolveGroup(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var groupPayload models.GroupPayload
+	if err := json.Unmarshal(data, &groupPayload); err != nil {
+		logger.Errorf("Error unmarshaling dissolve group payload: %v", err)
+		c.sendErrorMessage("Invalid group data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateGroupPayload(&groupPayload); err != nil {
+		logger.Errorf("Invalid dissolve group payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	var columnID string
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, "client:group:dissolve"); err != nil {
+			return err
+		}
+
+		column, group := board.FindGroup(groupPayload.GroupID)
+		if group == nil {
+			return errGroupNotFound
+		}
+		column.RemoveGroup(group.ID)
+		columnID = column.ID
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return
+	}
+	if err == errGroupNotFound {
+		c.sendErrorMessage("Group not found")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error dissolving group %s: %v", groupPayload.GroupID, err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventGroupDissolved, models.GroupDissolvedEventPayload{
+		ColumnID: columnID,
+		GroupID:  groupPayload.GroupID,
+	})
+}
+
+func (c *Client) handleVoteGroup(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var votePayload models.VoteGroupPayload
+	if err := json.Unmarshal(data, &votePayload); err != nil {
+		logger.Errorf("Error unmarshaling vote group payload: %v", err)
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateVoteGroupPayload(&votePayload); err != nil {
+		logger.Errorf("Invalid vote group payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	board := c.updateGroup("client:group:vote", votePayload.GroupID, func(board *models.Board, column *models.Column, group *models.Group) error {
+		// A group counts as the user's own if it holds any tile they wrote
+		selfAuthored := false
+		for _, tileID := range group.TileIDs {
+			if tile := column.FindTile(tileID); tile != nil && tile.AuthorID == c.userID {
+				selfAuthored = true
+			}
+		}
+
+		voterIDs, err := c.castVote(board, group.VoterIDs, selfAuthored, votePayload.Remove)
+		if err != nil {
+			return err
+		}
+		group.VoterIDs = voterIDs
+		votedAt := time.Now()
+		board.LastVoteAt = &votedAt
+		column.RefreshGroupVotes()
+		return nil
+	})
+	if board != nil {
+		c.sendVotesRemaining(board)
+	}
+}
+
+// updateGroup applies mutate to a group and broadcasts the updated group. It
+// returns the updated board, or nil if the update failed.
+func (c *Client) updateGroup(messageType, groupID string, mutate func(board *models.Board, column *models.Column, group *models.Group) error) *models.Board {
+	var columnID string
+	var updated *models.Group
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		if err := checkPhase(board, messageType); err != nil {
+			return err
+		}
+
+		column, group := board.FindGroup(groupID)
+		if group == nil {
+			return errGroupNotFound
+		}
+		if err := mutate(board, column, group); err != nil {
+			return err
+		}
+		columnID = column.ID
+		updated = group
+		return nil
+	})
+	if c.sendPhaseError(err) {
+		return nil
+	}
+	if err == errGroupNotFound {
+		c.sendErrorMessage("Group not found")
+		return nil
+	}
+	if err == errTileNotFound {
+		c.sendErrorMessage("Grouped tiles must belong to the group's column")
+		return nil
+	}
+	if err == errTileGrouped {
+		c.sendErrorMessage("Tile is already in a group")
+		return nil
+	}
+	if message, ok := voteErrorMessage(err); ok {
+		c.sendErrorMessage(message)
+		return nil
+	}
+	if err != nil {
+		logger.Errorf("Error updating group %s: %v", groupID, err)
+		return nil
+	}
+
+	c.broadcastEvent(board, models.EventGroupUpdated, models.NewGroupEventPayload(board.Columns[columnID], updated))
+	return board
+}
+
+func containsID(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
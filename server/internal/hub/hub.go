@@ -1,18 +1,26 @@
 package hub
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/google/uuid"
+	"live-retro-server/internal/archive"
+	"live-retro-server/internal/auth"
 	"live-retro-server/internal/logger"
 	"live-retro-server/internal/models"
 	"live-retro-server/internal/monitoring"
+	"live-retro-server/internal/pubsub"
 	"live-retro-server/internal/store"
 )
 
+// participantCookieMaxAge is how long a browser keeps its participant identity
+const participantCookieMaxAge = 7 * 24 * time.Hour
+
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
 		return true // Allow all origins for development
@@ -25,100 +33,214 @@ type Client struct {
 	send     chan []byte
 	boardID  string
 	userID   string
-	isAdmin  bool
+	role     models.Role
+	resuming bool // client will send client:sync:resume instead of taking a snapshot
+	room     *boardRoom
+
+	// dropped is set once the room has closed send; only read and written on
+	// the room goroutine
+	dropped bool
+}
+
+func (c *Client) isAdmin() bool {
+	return c.role == models.RoleAdmin
+}
+
+func (c *Client) viewer() models.Viewer {
+	return models.Viewer{Role: c.role, UserID: c.userID}
 }
 
 type Hub struct {
-	clients    map[string]map[*Client]bool // boardID -> clients
-	broadcast  chan []byte
-	register   chan *Client
-	unregister chan *Client
-	store      *store.RedisStore
-	cleanup    chan string // boardID to cleanup
+	rooms      map[string]*boardRoom // boardID -> active board actor
+	stopping   map[string]*boardRoom // boardID -> last stopped room, until it has unsubscribed
+	roomsMu    sync.Mutex
+	store      store.BoardStore
+	tokens     *auth.TokenSigner
+	broker     pubsub.Broker
+	archive    archive.Archive // nil unless archiving is enabled
+	maxColumns int             // per board
+	instanceID string
+
+	eventLogs   map[string]*eventLog // boardID -> recent events
+	eventLogsMu sync.Mutex
 }
 
-func NewHub(store *store.RedisStore) *Hub {
+// NewHub creates a hub; archive may be nil to keep no record of boards after
+// they expire. maxColumns caps the columns a board may be given.
+func NewHub(store store.BoardStore, tokens *auth.TokenSigner, broker pubsub.Broker, archive archive.Archive, maxColumns int) *Hub {
 	hub := &Hub{
-		clients:    make(map[string]map[*Client]bool),
-		broadcast:  make(chan []byte, 256),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
+		rooms:      make(map[string]*boardRoom),
+		stopping:   make(map[string]*boardRoom),
 		store:      store,
-		cleanup:    make(chan string, 256),
+		tokens:     tokens,
+		broker:     broker,
+		archive:    archive,
+		maxColumns: maxColumns,
+		instanceID: uuid.New().String(),
+		eventLogs:  make(map[string]*eventLog),
 	}
-	
-	// Start cleanup routine for expired boards
-	go hub.cleanupExpiredBoards()
-	
+
+	// Receive events from other server instances
+	go hub.consumeBroker()
+
 	return hub
 }
 
+// Run periodically disconnects clients from boards that have expired and,
+// with archiving enabled, archives boards shortly before they expire
 func (h *Hub) Run() {
+	ticker := time.NewTicker(5 * time.Minute) // Check every 5 minutes
+	defer ticker.Stop()
+
+	var archiveTicks <-chan time.Time
+	if h.archive != nil {
+		archiveTicker := time.NewTicker(archiveSweepInterval)
+		defer archiveTicker.Stop()
+		archiveTicks = archiveTicker.C
+	}
+
 	for {
 		select {
-		case client := <-h.register:
-			if h.clients[client.boardID] == nil {
-				h.clients[client.boardID] = make(map[*Client]bool)
-			}
-			h.clients[client.boardID][client] = true
-			
-			// Send current board state to new client
-			board, err := h.store.GetBoard(client.boardID)
-			if err != nil {
-				logger.Errorf("Error getting board %s: %v", client.boardID, err)
-				continue
-			}
-			
-			boardStateMsg := models.WebSocketMessage{
-				Type:    "server:board:state_update",
-				Payload: board,
-			}
-			
-			data, err := json.Marshal(boardStateMsg)
-			if err != nil {
-				logger.Errorf("Error marshaling board state: %v", err)
-				continue
-			}
-			
-			select {
-			case client.send <- data:
-			default:
-				close(client.send)
-				delete(h.clients[client.boardID], client)
-			}
-
-		case client := <-h.unregister:
-			if clients, ok := h.clients[client.boardID]; ok {
-				if _, ok := clients[client]; ok {
-					delete(clients, client)
-					close(client.send)
-					if len(clients) == 0 {
-						delete(h.clients, client.boardID)
-					}
-				}
-			}
-
-		case boardID := <-h.cleanup:
-			h.cleanupBoard(boardID)
+		case <-ticker.C:
+			h.cleanupExpiredBoards()
+		case <-archiveTicks:
+			h.archiveExpiringBoards()
 		}
 	}
 }
 
-func (h *Hub) BroadcastToBoard(boardID string, message []byte) {
-	if clients, ok := h.clients[boardID]; ok {
-		for client := range clients {
-			select {
-			case client.send <- message:
-			default:
-				close(client.send)
-				delete(clients, client)
-			}
+// joinRoom returns the room for a board, starting it if needed, and reserves
+// a place for one client so the room cannot stop before the client registers
+func (h *Hub) joinRoom(boardID string) *boardRoom {
+	h.roomsMu.Lock()
+	defer h.roomsMu.Unlock()
+
+	room, ok := h.rooms[boardID]
+	if !ok {
+		room = newBoardRoom(h, boardID)
+		if previous, ok := h.stopping[boardID]; ok {
+			room.previous = previous.done
+		}
+		h.rooms[boardID] = room
+		go room.run()
+	}
+	room.refs++
+
+	return room
+}
+
+// releaseRoom gives up one client's place in the room. It reports true when
+// that was the last client, in which case the room has been removed and its
+// goroutine must stop.
+func (h *Hub) releaseRoom(room *boardRoom) bool {
+	h.roomsMu.Lock()
+	defer h.roomsMu.Unlock()
+
+	room.refs--
+	if room.refs > 0 {
+		return false
+	}
+
+	delete(h.rooms, room.boardID)
+	// A new room for the board must not subscribe until this one unsubscribed
+	h.stopping[room.boardID] = room
+	return true
+}
+
+// forgetStopped drops the record of a room that has finished unsubscribing,
+// unless a later room has stopped since
+func (h *Hub) forgetStopped(room *boardRoom) {
+	h.roomsMu.Lock()
+	defer h.roomsMu.Unlock()
+
+	if h.stopping[room.boardID] == room {
+		delete(h.stopping, room.boardID)
+	}
+}
+
+// dispatch runs op on the board's room goroutine if the board has clients on
+// this instance. It reports false if there is no room to run it.
+func (h *Hub) dispatch(boardID string, op func(room *boardRoom)) bool {
+	h.roomsMu.Lock()
+	room, ok := h.rooms[boardID]
+	h.roomsMu.Unlock()
+
+	if !ok {
+		return false
+	}
+	return room.submit(func() { op(room) })
+}
+
+// dispatchAndWait runs op on the board's room goroutine like dispatch and
+// waits for it to finish. It reports false if no room ran op, including when
+// the room stopped with op still queued.
+func (h *Hub) dispatchAndWait(boardID string, op func(room *boardRoom)) bool {
+	h.roomsMu.Lock()
+	room, ok := h.rooms[boardID]
+	h.roomsMu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	ran := make(chan struct{})
+	if !room.submit(func() {
+		op(room)
+		close(ran)
+	}) {
+		return false
+	}
+
+	select {
+	case <-ran:
+		return true
+	case <-room.done:
+		// The room may have run op just before stopping
+		select {
+		case <-ran:
+			return true
+		default:
+			return false
 		}
 	}
 }
 
-func (h *Hub) HandleWebSocket(w http.ResponseWriter, r *http.Request, boardID, adminKey string) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+// deliverEvent sends an event to this instance's clients on the board
+func (h *Hub) deliverEvent(boardID string, event models.WebSocketMessage) {
+	delivered := h.dispatch(boardID, func(room *boardRoom) {
+		room.deliver(event)
+	})
+
+	// Keep the log complete for clients that reconnect to this instance
+	if !delivered && event.Seq > 0 {
+		h.eventLogFor(boardID).append(event)
+	}
+}
+
+func marshalBoardState(board *models.Board, viewer models.Viewer) ([]byte, error) {
+	return json.Marshal(models.WebSocketMessage{
+		Type:    models.EventBoardState,
+		Seq:     board.Version,
+		Payload: models.ProjectBoard(board, viewer),
+	})
+}
+
+func (h *Hub) HandleWebSocket(w http.ResponseWriter, r *http.Request, boardID, adminKey, participantToken string, requestedRole models.Role, resuming bool) {
+	// Reuse the participant's identity if they present a valid token
+	userID, token := h.resolveParticipant(r, boardID, participantToken)
+
+	responseHeader := http.Header{}
+	responseHeader.Add("Set-Cookie", (&http.Cookie{
+		Name:     participantCookieName(boardID),
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(participantCookieMaxAge / time.Second),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	}).String())
+
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
 	if err != nil {
 		logger.Errorf("WebSocket upgrade error: %v", err)
 		return
@@ -136,28 +258,48 @@ func (h *Hub) HandleWebSocket(w http.ResponseWriter, r *http.Request, boardID, a
 		return
 	}
 
-	// Generate user ID and check if admin
-	userID := generateUserID()
-	isAdmin := adminKey != "" && h.isValidAdmin(boardID, adminKey)
+	// Check if admin
+	role := requestedRole
+	if adminKey != "" && h.IsValidAdmin(boardID, adminKey) {
+		role = models.RoleAdmin
+	}
 
-	logger.Debugf("New WebSocket connection: board=%s, user=%s, admin=%t", boardID, userID, isAdmin)
+	logger.Debugf("New WebSocket connection: board=%s, user=%s, role=%s", boardID, userID, role)
 
 	client := &Client{
-		hub:     h,
-		conn:    conn,
-		send:    make(chan []byte, 256),
-		boardID: boardID,
-		userID:  userID,
-		isAdmin: isAdmin,
+		hub:      h,
+		conn:     conn,
+		send:     make(chan []byte, 256),
+		boardID:  boardID,
+		userID:   userID,
+		role:     role,
+		resuming: resuming,
+		room:     h.joinRoom(boardID),
+	}
+
+	// Tell the client who it is so it can present the token when reconnecting
+	if data, err := json.Marshal(models.WebSocketMessage{
+		Type: "server:participant:identity",
+		Payload: map[string]interface{}{
+			"userId": userID,
+			"token":  token,
+		},
+	}); err == nil {
+		client.send <- data
 	}
 
-	h.register <- client
+	client.room.register <- client
 
 	go client.writePump()
 	go client.readPump()
 }
 
-func (h *Hub) isValidAdmin(boardID, adminKey string) bool {
+// updateBoard applies mutate to the stored board, retrying on concurrent writes
+func (h *Hub) updateBoard(boardID string, mutate func(board *models.Board) error) (*models.Board, error) {
+	return store.UpdateBoard(context.Background(), h.store, boardID, mutate)
+}
+
+func (h *Hub) IsValidAdmin(boardID, adminKey string) bool {
 	board, err := h.store.GetBoard(boardID)
 	if err != nil {
 		return false
@@ -169,64 +311,61 @@ func generateUserID() string {
 	return "user_" + uuid.New().String()[:8]
 }
 
-// cleanupExpiredBoards runs periodically to clean up connections to expired boards
-func (h *Hub) cleanupExpiredBoards() {
-	ticker := time.NewTicker(5 * time.Minute) // Check every 5 minutes
-	defer ticker.Stop()
-	
-	for range ticker.C {
-		// Get all board IDs that have active connections
-		var boardIDs []string
-		for boardID := range h.clients {
-			boardIDs = append(boardIDs, boardID)
+// resolveParticipant returns the user ID carried by a valid participant token,
+// taken from the query parameter or the board's cookie, or mints a new one.
+// The returned token identifies the user on this board.
+func (h *Hub) resolveParticipant(r *http.Request, boardID, participantToken string) (string, string) {
+	if participantToken == "" {
+		if cookie, err := r.Cookie(participantCookieName(boardID)); err == nil {
+			participantToken = cookie.Value
 		}
-		
-		// Check if each board still exists in Redis
-		for _, boardID := range boardIDs {
-			if !h.store.BoardExists(boardID) {
-				logger.Debugf("Cleaning up expired board: %s", boardID)
-				select {
-				case h.cleanup <- boardID:
-				default:
-					logger.Warnf("Cleanup channel full, skipping board: %s", boardID)
-				}
-			}
+	}
+
+	if participantToken != "" {
+		if userID, err := h.tokens.Verify(participantToken, boardID); err == nil {
+			return userID, participantToken
 		}
+		logger.Debugf("Ignoring invalid participant token for board %s", boardID)
 	}
+
+	userID := generateUserID()
+	return userID, h.tokens.Issue(boardID, userID)
 }
 
-// cleanupBoard forcibly disconnects all clients from an expired board
-func (h *Hub) cleanupBoard(boardID string) {
-	if clients, ok := h.clients[boardID]; ok {
-		logger.Infof("Cleaning up %d clients for expired board: %s", len(clients), boardID)
-		
-		// Send close message to all clients
-		closeMsg := models.WebSocketMessage{
-			Type: "server:board:expired",
-			Payload: map[string]interface{}{
-				"message": "Board has expired due to inactivity",
-			},
-		}
-		
-		if data, err := json.Marshal(closeMsg); err == nil {
-			for client := range clients {
-				select {
-				case client.send <- data:
-				default:
-					// Channel might be closed, ignore
-				}
-				// Force close the connection
-				client.conn.Close()
-			}
+func participantCookieName(boardID string) string {
+	return "retro_participant_" + boardID
+}
+
+// cleanupExpiredBoards disconnects clients from boards that no longer exist
+func (h *Hub) cleanupExpiredBoards() {
+	// Get all board IDs that have active connections
+	h.roomsMu.Lock()
+	var boardIDs []string
+	for boardID := range h.rooms {
+		boardIDs = append(boardIDs, boardID)
+	}
+	h.roomsMu.Unlock()
+
+	// Event logs can outlive a board's connections; drop them once the board is gone
+	h.eventLogsMu.Lock()
+	var loggedBoardIDs []string
+	for boardID := range h.eventLogs {
+		loggedBoardIDs = append(loggedBoardIDs, boardID)
+	}
+	h.eventLogsMu.Unlock()
+	for _, boardID := range loggedBoardIDs {
+		if !h.store.BoardExists(boardID) {
+			h.dropEventLog(boardID)
 		}
-		
-		// Remove all clients for this board and adjust connection count
-		clientCount := len(clients)
-		delete(h.clients, boardID)
-		
-		// Decrement connection counter for each closed client
-		for i := 0; i < clientCount; i++ {
-			monitoring.DecrementConnections()
+	}
+
+	// Check if each board still exists in the store
+	for _, boardID := range boardIDs {
+		if !h.store.BoardExists(boardID) {
+			logger.Debugf("Cleaning up expired board: %s", boardID)
+			h.dispatch(boardID, func(room *boardRoom) {
+				room.expire()
+			})
 		}
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,113 @@
+package hub
+
+import (
+	"encoding/json"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+// phaseRestrictions lists the phases in which each restricted message is
+// accepted. Messages not listed here are accepted in every phase.
+var phaseRestrictions = map[string][]models.Phase{
+	"client:tile:create":       {models.PhaseBrainstorm},
+	"client:tile:update":       {models.PhaseBrainstorm, models.PhaseGroup},
+	"client:tile:delete":       {models.PhaseBrainstorm, models.PhaseGroup},
+	"client:group:create":      {models.PhaseGroup},
+	"client:group:rename":      {models.PhaseGroup},
+	"client:group:add_tile":    {models.PhaseGroup},
+	"client:group:remove_tile": {models.PhaseGroup},
+	"client:group:dissolve":    {models.PhaseGroup},
+	"client:tile:vote":         {models.PhaseVote},
+	"client:group:vote":        {models.PhaseVote},
+	"client:thread:create":     {models.PhaseDiscuss, models.PhaseActionItems},
+	"client:action:create":     {models.PhaseDiscuss, models.PhaseActionItems},
+	"client:action:promote":    {models.PhaseDiscuss, models.PhaseActionItems},
+}
+
+// phaseError rejects a message the board's current phase does not accept
+type phaseError struct {
+	phase models.Phase
+}
+
+func (e *phaseError) Error() string {
+	return "This action is not available during the " + string(e.phase) + " phase"
+}
+
+// checkPhase returns a *phaseError unless the board's current phase accepts
+// the message. Handlers call it inside their mutation, so the phase is checked
+// on the same version of the board that the change is made to.
+func checkPhase(board *models.Board, messageType string) error {
+	allowed, restricted := phaseRestrictions[messageType]
+	if !restricted {
+		return nil
+	}
+
+	phase := board.CurrentPhase()
+	for _, p := range allowed {
+		if p == phase {
+			return nil
+		}
+	}
+	return &phaseError{phase: phase}
+}
+
+// sendPhaseError tells the client why its message was rejected if err is a
+// *phaseError, reporting whether it was
+func (c *Client) sendPhaseError(err error) bool {
+	phaseErr, ok := err.(*phaseError)
+	if ok {
+		c.sendErrorMessage(phaseErr.Error())
+	}
+	return ok
+}
+
+func (c *Client) handleAdvancePhase(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var advancePayload models.AdvancePhasePayload
+	if err := json.Unmarshal(data, &advancePayload); err != nil {
+		logger.Errorf("Error unmarshaling advance phase payload: %v", err)
+		c.sendErrorMessage("Invalid phase data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateAdvancePhasePayload(&advancePayload); err != nil {
+		logger.Errorf("Invalid advance phase payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		next := advancePayload.Phase
+		if next == "" {
+			next = board.NextPhase()
+		}
+		if !board.PhaseEnabled(next) {
+			return errPhaseDisabled
+		}
+		if next == board.CurrentPhase() {
+			return errNoChange
+		}
+		board.Phase = next
+		// Each phase is time-boxed on its own, so moving on clears the timer
+		board.Timer = nil
+		return nil
+	})
+	if err == errNoChange {
+		c.sendErrorMessage("The board is already in that phase")
+		return
+	}
+	if err == errPhaseDisabled {
+		c.sendErrorMessage("That phase is not part of this retro")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error advancing phase: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventPhaseChanged, models.PhaseEventPayload{
+		Phase: board.Phase,
+	})
+}
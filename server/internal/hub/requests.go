@@ -0,0 +1,99 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+	"live-retro-server/internal/store"
+)
+
+// ParticipantTokenHeader carries a participant token on REST requests, the
+// way the participantToken parameter does for WebSocket connections
+const ParticipantTokenHeader = "X-Participant-Token"
+
+// HandleRequest applies a client message that arrived over REST instead of
+// the WebSocket. The caller is handled as a client without a connection: the
+// message goes through the same checks and handlers, and the change reaches
+// every connected client. If the board has a room on this instance the
+// message is handled there, in order with the room's other events; otherwise
+// it is handled here, and a room started later schedules any running timer
+// from the store. The response is the first message the caller would have
+// been sent, normally the resulting board event.
+func (h *Hub) HandleRequest(w http.ResponseWriter, r *http.Request, boardID, adminKey, participantToken string, msg models.WebSocketMessage) {
+	board, err := h.store.GetBoard(boardID)
+	if errors.Is(err, store.ErrBoardNotFound) {
+		http.Error(w, "Board not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error getting board %s: %v", boardID, err)
+		http.Error(w, "Failed to load board", http.StatusInternalServerError)
+		return
+	}
+
+	role := models.RoleParticipant
+	if adminKey != "" {
+		if adminKey != board.AdminKey {
+			http.Error(w, "Invalid admin key", http.StatusForbidden)
+			return
+		}
+		role = models.RoleAdmin
+	}
+	if adminOnly[msg.Type] && role != models.RoleAdmin {
+		http.Error(w, "Only the board admin can do this", http.StatusForbidden)
+		return
+	}
+
+	userID, token := h.resolveParticipant(r, boardID, participantToken)
+	w.Header().Set(ParticipantTokenHeader, token)
+
+	logger.Debugf("REST request: type=%s, board=%s, user=%s", msg.Type, boardID, userID)
+
+	client := &Client{
+		hub:     h,
+		send:    make(chan []byte, 16),
+		boardID: boardID,
+		userID:  userID,
+		role:    role,
+	}
+	if !h.dispatchAndWait(boardID, func(room *boardRoom) {
+		client.room = room
+		client.handleMessage(msg)
+	}) {
+		client.handleMessage(msg)
+	}
+
+	reply, ok := firstReply(client.send)
+	if !ok {
+		// Handlers log rather than report some failures, such as unknown IDs
+		http.Error(w, "The request was not applied", http.StatusUnprocessableEntity)
+		return
+	}
+
+	var decoded struct {
+		Type    string `json:"type"`
+		Payload struct {
+			Message string `json:"message"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(reply, &decoded); err == nil && decoded.Type == "error" {
+		http.Error(w, decoded.Payload.Message, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(reply)
+}
+
+// firstReply takes the first message queued on send, if any
+func firstReply(send chan []byte) ([]byte, bool) {
+	select {
+	case reply, ok := <-send:
+		return reply, ok
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,164 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+	"live-retro-server/internal/store"
+)
+
+// minExpiryCheckDelay keeps expiry checks from spinning while a board that is
+// due to expire has not been deleted yet
+const minExpiryCheckDelay = time.Second
+
+// handleExtendBoard keeps the board for the given number of minutes beyond
+// its current expiry
+func (c *Client) handleExtendBoard(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var extendPayload models.ExtendBoardPayload
+	if err := json.Unmarshal(data, &extendPayload); err != nil {
+		logger.Errorf("Error unmarshaling extend board payload: %v", err)
+		c.sendErrorMessage("Invalid extension data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateExtendBoardPayload(&extendPayload); err != nil {
+		logger.Errorf("Invalid extend board payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	now := time.Now()
+	retention := c.hub.store.Retention()
+	var limit error
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		from := board.ExpiresAt
+		if from.Before(now) {
+			from = now
+		}
+		keepUntil := from.Add(time.Duration(extendPayload.Minutes) * time.Minute)
+		if limit = retention.ValidateKeepUntil(keepUntil, now); limit != nil {
+			return errRetentionLimit
+		}
+		board.KeepUntil = &keepUntil
+		return nil
+	})
+	if err == errRetentionLimit {
+		c.sendErrorMessage(limit.Error())
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error extending board: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventRetentionUpdated, retentionPayload(board))
+}
+
+// handleKeepBoardUntil keeps the board until a set time, or hands it back to
+// its inactivity TTL when no time is given
+func (c *Client) handleKeepBoardUntil(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var keepPayload models.KeepBoardUntilPayload
+	if err := json.Unmarshal(data, &keepPayload); err != nil {
+		logger.Errorf("Error unmarshaling keep board payload: %v", err)
+		c.sendErrorMessage("Invalid retention data")
+		return
+	}
+
+	// Validate payload
+	if keepPayload.KeepUntil != nil {
+		if err := c.hub.store.Retention().ValidateKeepUntil(*keepPayload.KeepUntil, time.Now()); err != nil {
+			logger.Errorf("Invalid keep board payload: %v", err)
+			c.sendErrorMessage(err.Error())
+			return
+		}
+	}
+
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		board.KeepUntil = keepPayload.KeepUntil
+		return nil
+	})
+	if err != nil {
+		logger.Errorf("Error updating board retention: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventRetentionUpdated, retentionPayload(board))
+}
+
+func retentionPayload(board *models.Board) models.RetentionEventPayload {
+	return models.RetentionEventPayload{
+		TTLMinutes: board.TTLMinutes,
+		KeepUntil:  board.KeepUntil,
+		ExpiresAt:  board.ExpiresAt,
+	}
+}
+
+// scheduleExpiryCheck arms the room's expiry check to run after delay,
+// replacing any earlier schedule. Must be called on the room goroutine.
+func (r *boardRoom) scheduleExpiryCheck(delay time.Duration) {
+	r.stopExpiryCheck()
+	if delay < minExpiryCheckDelay {
+		delay = minExpiryCheckDelay
+	}
+
+	r.expiryCheck = time.AfterFunc(delay, func() {
+		r.submit(r.checkExpiry)
+	})
+}
+
+func (r *boardRoom) stopExpiryCheck() {
+	if r.expiryCheck != nil {
+		r.expiryCheck.Stop()
+		r.expiryCheck = nil
+	}
+}
+
+// checkExpiry warns the room's clients once the board is about to expire and
+// schedules the next check. Every change pushes the expiry back, so the stored
+// board is read each time rather than trusting an earlier schedule. Each
+// instance warns only its own clients. Must be called on the room goroutine.
+func (r *boardRoom) checkExpiry() {
+	board, err := r.hub.store.GetBoard(r.boardID)
+	if errors.Is(err, store.ErrBoardNotFound) {
+		r.expire()
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error getting board %s: %v", r.boardID, err)
+		r.scheduleExpiryCheck(time.Minute)
+		return
+	}
+
+	now := time.Now()
+	if board.ExpiresAt.IsZero() {
+		// Saved before expiry times were recorded; the next change sets one
+		r.scheduleExpiryCheck(time.Minute)
+		return
+	}
+
+	warnAt := board.ExpiresAt.Add(-r.hub.store.Retention().ExpiryWarningLead(board))
+	if now.Before(warnAt) {
+		r.scheduleExpiryCheck(warnAt.Sub(now))
+		return
+	}
+
+	if !board.ExpiresAt.Equal(r.warnedExpiry) {
+		r.warnedExpiry = board.ExpiresAt
+		r.deliver(models.WebSocketMessage{
+			Type: "server:board:expiring_soon",
+			Payload: models.ExpiringSoonPayload{
+				ExpiresAt:  board.ExpiresAt,
+				ServerTime: now,
+			},
+		})
+	}
+
+	// Look again once it is due, to find it deleted or extended
+	r.scheduleExpiryCheck(board.ExpiresAt.Sub(now))
+}
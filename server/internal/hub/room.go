@@ -0,0 +1,249 @@
+package hub
+
+import (
+	"encoding/json"
+	"time"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+// boardRoom is the actor for one active board. Its goroutine owns the board's
+// client set and runs every mutation and broadcast for the board in order, so
+// boards progress independently and no client map is shared between
+// goroutines. The room stops itself once its last client leaves.
+type boardRoom struct {
+	hub     *Hub
+	boardID string
+	clients map[*Client]bool
+
+	// refs counts clients that joined or are joining; guarded by hub.roomsMu
+	refs int
+
+	register   chan *Client
+	unregister chan *Client
+	ops        chan func()
+	done       chan struct{}
+
+	// previous is closed once the board's previous room on this instance has
+	// unsubscribed from the broker, if it was still stopping when this room
+	// started
+	previous <-chan struct{}
+
+	// countdown fires when the board's timer is due to run out
+	countdown *time.Timer
+
+	// expiryCheck fires when the board may be about to expire; warnedExpiry
+	// is the expiry clients were last warned about
+	expiryCheck  *time.Timer
+	warnedExpiry time.Time
+}
+
+func newBoardRoom(hub *Hub, boardID string) *boardRoom {
+	return &boardRoom{
+		hub:        hub,
+		boardID:    boardID,
+		clients:    make(map[*Client]bool),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		ops:        make(chan func(), 256),
+		done:       make(chan struct{}),
+	}
+}
+
+func (r *boardRoom) run() {
+	defer close(r.done)
+	defer r.leaveBroker()
+	defer r.scheduleCountdown(nil)
+	defer r.stopExpiryCheck()
+
+	r.joinBroker()
+	r.scheduleCountdownFromStore()
+	r.checkExpiry()
+
+	for {
+		select {
+		case client := <-r.register:
+			r.clients[client] = true
+
+			// Resuming clients catch up from the event log instead
+			if !client.resuming {
+				client.sendBoardState()
+			}
+
+		case client := <-r.unregister:
+			if _, ok := r.clients[client]; ok {
+				r.drop(client)
+			}
+			if r.hub.releaseRoom(r) {
+				return
+			}
+
+		case op := <-r.ops:
+			op()
+		}
+	}
+}
+
+// joinBroker subscribes to events from other instances. Broker round trips
+// happen on the room goroutine so a slow broker only holds up this board.
+func (r *boardRoom) joinBroker() {
+	if r.previous != nil {
+		<-r.previous
+	}
+	r.hub.subscribeBoard(r.boardID)
+}
+
+// leaveBroker unsubscribes once the room has stopped
+func (r *boardRoom) leaveBroker() {
+	r.hub.unsubscribeBoard(r.boardID)
+	r.hub.forgetStopped(r)
+}
+
+// submit queues op to run on the room goroutine. It reports false if the room
+// has already stopped.
+func (r *boardRoom) submit(op func()) bool {
+	select {
+	case r.ops <- op:
+		return true
+	case <-r.done:
+		return false
+	}
+}
+
+// broadcastEvent delivers an event to this room's clients and publishes it to
+// other instances. Must be called on the room goroutine.
+func (r *boardRoom) broadcastEvent(event models.WebSocketMessage) {
+	r.deliver(event)
+	r.hub.publishEvent(r.boardID, event)
+}
+
+// deliver sends an event to the room's clients, filtered for each client's
+// role. Each distinct view is marshaled only once, unless the view depends on
+// the individual recipient. Must be called on the room goroutine.
+func (r *boardRoom) deliver(event models.WebSocketMessage) {
+	// Only sequenced board changes are kept for resuming clients
+	if event.Seq > 0 {
+		r.hub.eventLogFor(r.boardID).append(event)
+	}
+
+	views := make(map[models.Role][]byte)
+	for client := range r.clients {
+		data, ok := views[client.role]
+		if !ok {
+			var err error
+			data, err = json.Marshal(models.ProjectEvent(event, client.viewer()))
+			if err != nil {
+				logger.Errorf("Error marshaling %s event for broadcast: %v", event.Type, err)
+				return
+			}
+			if !models.EventNeedsViewer(event, client.role) {
+				views[client.role] = data
+			}
+		}
+
+		if !client.queue(data) {
+			r.drop(client)
+		}
+	}
+
+	if changesVoteBudgets(event.Type) {
+		r.sendVotesRemaining()
+	}
+
+	if payload, ok := event.Payload.(models.TimerEventPayload); ok {
+		r.scheduleCountdown(payload.Timer)
+	}
+
+	// A phase change can reveal or hide votes, which a delta cannot express
+	if changesPhase(event) {
+		r.sendBoardState()
+	}
+
+	if event.Type == models.EventBoardClosed {
+		r.disconnectAll()
+	}
+}
+
+func changesPhase(event models.WebSocketMessage) bool {
+	if event.Type == models.EventPhaseChanged {
+		return true
+	}
+	payload, ok := event.Payload.(models.TimerExpiredEventPayload)
+	return ok && payload.Advanced
+}
+
+// sendBoardState sends every client a fresh snapshot. Must be called on the
+// room goroutine.
+func (r *boardRoom) sendBoardState() {
+	board, err := r.hub.store.GetBoard(r.boardID)
+	if err != nil {
+		logger.Errorf("Error getting board %s: %v", r.boardID, err)
+		return
+	}
+
+	for client := range r.clients {
+		client.sendBoard(board)
+	}
+}
+
+// sendVotesRemaining refreshes every client's vote budget. Must be called on
+// the room goroutine.
+func (r *boardRoom) sendVotesRemaining() {
+	board, err := r.hub.store.GetBoard(r.boardID)
+	if err != nil {
+		logger.Errorf("Error getting board %s: %v", r.boardID, err)
+		return
+	}
+
+	for client := range r.clients {
+		client.sendVotesRemaining(board)
+	}
+}
+
+// expire tells every client the board is gone and closes their connections.
+// Their read pumps then unregister them, which stops the room.
+func (r *boardRoom) expire() {
+	logger.Infof("Cleaning up %d clients for expired board: %s", len(r.clients), r.boardID)
+
+	closeMsg := models.WebSocketMessage{
+		Type: "server:board:expired",
+		Payload: map[string]interface{}{
+			"message": "Board has expired due to inactivity",
+		},
+	}
+
+	data, err := json.Marshal(closeMsg)
+	if err != nil {
+		logger.Errorf("Error marshaling expiry message: %v", err)
+	}
+
+	for client := range r.clients {
+		if data != nil {
+			client.queue(data)
+		}
+	}
+	r.disconnectAll()
+}
+
+// disconnectAll drops every client from the room. Their write pumps flush the
+// messages already queued before closing the connection, and their read pumps
+// then unregister them, which stops the room. Must be called on the room
+// goroutine.
+func (r *boardRoom) disconnectAll() {
+	for client := range r.clients {
+		r.drop(client)
+	}
+}
+
+// drop removes a client from the room and closes its send channel, which
+// makes its write pump close the connection. Messages the client sent before
+// it was dropped may still be queued; they are skipped. Must be called on the
+// room goroutine.
+func (r *boardRoom) drop(client *Client) {
+	delete(r.clients, client)
+	if !client.dropped {
+		client.dropped = true
+		close(client.send)
+	}
+}
@@ -0,0 +1,47 @@
+package hub
+
+import "testing"
+
+func TestDispatchAndWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		room    bool
+		stopped bool
+		want    bool
+	}{
+		{name: "running room", room: true, want: true},
+		{name: "stopped room", room: true, stopped: true},
+		{name: "no room"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Hub{rooms: make(map[string]*boardRoom)}
+			if tt.room {
+				room := newBoardRoom(h, "board")
+				h.rooms["board"] = room
+				if tt.stopped {
+					close(room.done)
+				} else {
+					go func() {
+						for op := range room.ops {
+							op()
+						}
+					}()
+					defer close(room.ops)
+				}
+			}
+
+			ran := false
+			got := h.dispatchAndWait("board", func(room *boardRoom) {
+				ran = true
+			})
+			if got != tt.want {
+				t.Errorf("dispatchAndWait = %v, want %v", got, tt.want)
+			}
+			if ran != tt.want {
+				t.Errorf("op ran = %v, want %v", ran, tt.want)
+			}
+		})
+	}
+}
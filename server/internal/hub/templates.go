@@ -0,0 +1,84 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+// handleSaveBoardTemplate stores the board's current columns and settings as
+// a new template of the board's team. Only the admin who saved it learns the
+// template's edit key.
+func (c *Client) handleSaveBoardTemplate(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var savePayload models.SaveBoardTemplatePayload
+	if err := json.Unmarshal(data, &savePayload); err != nil {
+		logger.Errorf("Error unmarshaling save template payload: %v", err)
+		c.sendErrorMessage("Invalid template data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateSaveBoardTemplatePayload(&savePayload); err != nil {
+		logger.Errorf("Invalid save template payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	savePayload.Name = models.SanitizeString(savePayload.Name)
+	savePayload.Description = models.SanitizeString(savePayload.Description)
+	savePayload.IcebreakerPrompt = models.SanitizeString(savePayload.IcebreakerPrompt)
+
+	board, err := c.hub.store.GetBoard(c.boardID)
+	if err != nil {
+		logger.Errorf("Error getting board %s: %v", c.boardID, err)
+		return
+	}
+
+	if board.TeamID == "" {
+		c.sendErrorMessage("Only a team's boards can be saved as templates")
+		return
+	}
+
+	stored, err := c.hub.store.ListTeamTemplates(board.TeamID)
+	if err != nil {
+		logger.Errorf("Error listing templates of team %s: %v", board.TeamID, err)
+		c.sendErrorMessage("Failed to save template")
+		return
+	}
+	if len(stored) >= models.MaxTeamTemplates {
+		c.sendErrorMessage(fmt.Sprintf("A team may store at most %d templates", models.MaxTeamTemplates))
+		return
+	}
+
+	// Column titles on the board are already sanitized
+	template := models.TemplateFromBoard(board, &savePayload)
+	if err := models.ValidateTemplate(template); err != nil {
+		c.sendErrorMessage(err.Error())
+		return
+	}
+	template.ID = uuid.New().String()
+	template.EditKey = uuid.New().String()
+
+	if err := c.hub.store.SaveTemplate(template); err != nil {
+		logger.Errorf("Error saving template: %v", err)
+		c.sendErrorMessage("Failed to save template")
+		return
+	}
+
+	data, err = json.Marshal(models.WebSocketMessage{
+		Type:    "server:template:saved",
+		Payload: template,
+	})
+	if err != nil {
+		logger.Errorf("Error marshaling saved template: %v", err)
+		return
+	}
+
+	if !c.queue(data) {
+		logger.Errorf("Failed to send saved template to client")
+	}
+}
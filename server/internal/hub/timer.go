@@ -0,0 +1,175 @@
+package hub
+
+import (
+	"encoding/json"
+	"time"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+func (c *Client) handleStartTimer(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var startPayload models.StartTimerPayload
+	if err := json.Unmarshal(data, &startPayload); err != nil {
+		logger.Errorf("Error unmarshaling start timer payload: %v", err)
+		c.sendErrorMessage("Invalid timer data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateStartTimerPayload(&startPayload); err != nil {
+		logger.Errorf("Invalid start timer payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	c.updateTimer(func(board *models.Board, now time.Time) error {
+		if startPayload.DurationSeconds > 0 {
+			duration := time.Duration(startPayload.DurationSeconds) * time.Second
+			board.Timer = models.NewTimer(duration, startPayload.AutoAdvance, now)
+			return nil
+		}
+
+		// Without a duration, start resumes a paused timer
+		if board.Timer == nil || board.Timer.Running {
+			return errNoChange
+		}
+		board.Timer.Resume(now)
+		return nil
+	})
+}
+
+func (c *Client) handlePauseTimer(payload interface{}) {
+	c.updateTimer(func(board *models.Board, now time.Time) error {
+		if board.Timer == nil || !board.Timer.Running {
+			return errNoChange
+		}
+		board.Timer.Pause(now)
+		return nil
+	})
+}
+
+func (c *Client) handleExtendTimer(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var extendPayload models.ExtendTimerPayload
+	if err := json.Unmarshal(data, &extendPayload); err != nil {
+		logger.Errorf("Error unmarshaling extend timer payload: %v", err)
+		c.sendErrorMessage("Invalid timer data")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateExtendTimerPayload(&extendPayload); err != nil {
+		logger.Errorf("Invalid extend timer payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	c.updateTimer(func(board *models.Board, now time.Time) error {
+		if board.Timer == nil {
+			return errNoChange
+		}
+		board.Timer.Extend(time.Duration(extendPayload.Seconds) * time.Second)
+		return nil
+	})
+}
+
+func (c *Client) handleCancelTimer(payload interface{}) {
+	c.updateTimer(func(board *models.Board, now time.Time) error {
+		if board.Timer == nil {
+			return errNoChange
+		}
+		board.Timer = nil
+		return nil
+	})
+}
+
+// updateTimer applies mutate to the board's timer and broadcasts the result
+func (c *Client) updateTimer(mutate func(board *models.Board, now time.Time) error) {
+	var now time.Time
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		now = time.Now()
+		return mutate(board, now)
+	})
+	if err == errNoChange {
+		c.sendErrorMessage("No timer to change")
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error updating timer: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventTimerUpdated, models.TimerEventPayload{
+		Timer:      board.Timer,
+		ServerTime: now,
+	})
+}
+
+// scheduleCountdown arms the room's countdown to check the board's timer when
+// it is due to run out, replacing any earlier schedule. Must be called on the
+// room goroutine.
+func (r *boardRoom) scheduleCountdown(timer *models.Timer) {
+	if r.countdown != nil {
+		r.countdown.Stop()
+		r.countdown = nil
+	}
+	if timer == nil || !timer.Running {
+		return
+	}
+
+	r.countdown = time.AfterFunc(timer.Remaining(time.Now()), func() {
+		r.submit(r.expireTimer)
+	})
+}
+
+// scheduleCountdownFromStore arms the countdown for the stored board's timer
+func (r *boardRoom) scheduleCountdownFromStore() {
+	board, err := r.hub.store.GetBoard(r.boardID)
+	if err != nil {
+		return
+	}
+	r.scheduleCountdown(board.Timer)
+}
+
+// expireTimer ends the board's timer if it has run out, advancing the phase
+// when asked to. Every instance with clients on the board races to do this;
+// only the one whose update lands broadcasts the expiry.
+func (r *boardRoom) expireTimer() {
+	var pending *models.Timer
+	var expired models.TimerExpiredEventPayload
+	board, err := r.hub.updateBoard(r.boardID, func(board *models.Board) error {
+		pending = nil
+		if board.Timer == nil || !board.Timer.Running {
+			return errNoChange
+		}
+		if !board.Timer.Expired(time.Now()) {
+			// Extended elsewhere and we have not heard yet; check again later
+			pending = board.Timer
+			return errNoChange
+		}
+
+		expired = models.TimerExpiredEventPayload{}
+		if board.Timer.AutoAdvance {
+			next := board.NextPhase()
+			expired.Advanced = next != board.CurrentPhase()
+			board.Phase = next
+		}
+		board.Timer = nil
+		expired.Phase = board.CurrentPhase()
+		return nil
+	})
+	if err == errNoChange {
+		if pending != nil {
+			r.scheduleCountdown(pending)
+		}
+		return
+	}
+	if err != nil {
+		logger.Errorf("Error expiring timer for board %s: %v", r.boardID, err)
+		return
+	}
+
+	r.broadcastEvent(models.NewBoardEvent(board, models.EventTimerExpired, expired))
+}
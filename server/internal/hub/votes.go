@@ -0,0 +1,112 @@
+package hub
+
+import (
+	"encoding/json"
+
+	"live-retro-server/internal/logger"
+	"live-retro-server/internal/models"
+)
+
+// castVote adds or removes one of the client's votes in voterIDs, enforcing
+// the board's voting settings, and returns the new voter list. With one vote
+// per tile allowed, voting again takes the vote back.
+func (c *Client) castVote(board *models.Board, voterIDs []string, selfAuthored, remove bool) ([]string, error) {
+	mine := models.CountVotes(voterIDs, c.userID)
+	voting := board.VotingSettings()
+	perTile := voting.PerTileLimit()
+
+	if remove || (perTile == 1 && mine > 0) {
+		if mine == 0 {
+			return nil, errNoVoteToRemove
+		}
+		return models.RemoveID(voterIDs, c.userID), nil
+	}
+
+	if selfAuthored && !voting.AllowSelfVote {
+		return nil, errSelfVote
+	}
+	if mine >= perTile {
+		return nil, errTileVoteLimit
+	}
+	if budget := voting.VotesPerParticipant; budget > 0 && board.VotesCast(c.userID) >= budget {
+		return nil, errVoteBudgetSpent
+	}
+
+	return append(voterIDs, c.userID), nil
+}
+
+// voteErrorMessage returns the message to show a client for a rejected vote
+func voteErrorMessage(err error) (string, bool) {
+	switch err {
+	case errSelfVote:
+		return "You cannot vote on your own feedback", true
+	case errTileVoteLimit:
+		return "You have reached the vote limit for this item", true
+	case errVoteBudgetSpent:
+		return "You have no votes remaining", true
+	case errNoVoteToRemove:
+		return "You have not voted on this item", true
+	}
+	return "", false
+}
+
+func (c *Client) handleUpdateVotingSettings(payload interface{}) {
+	data, _ := json.Marshal(payload)
+	var settings models.VotingSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		logger.Errorf("Error unmarshaling voting settings payload: %v", err)
+		c.sendErrorMessage("Invalid voting settings")
+		return
+	}
+
+	// Validate payload
+	if err := models.ValidateVotingSettings(&settings); err != nil {
+		logger.Errorf("Invalid voting settings payload: %v", err)
+		c.sendErrorMessage(err.Error())
+		return
+	}
+
+	// Votes already cast are kept even if they exceed a lowered budget
+	board, err := c.hub.updateBoard(c.boardID, func(board *models.Board) error {
+		board.Voting = settings
+		return nil
+	})
+	if err != nil {
+		logger.Errorf("Error updating voting settings: %v", err)
+		return
+	}
+
+	c.broadcastEvent(board, models.EventVotingUpdated, settings)
+}
+
+// sendVotesRemaining tells this client how much of its vote budget is left
+func (c *Client) sendVotesRemaining(board *models.Board) {
+	if !c.role.CanEdit() {
+		return
+	}
+
+	data, err := json.Marshal(models.WebSocketMessage{
+		Type:    "server:votes:remaining",
+		Payload: board.VotesRemaining(c.userID),
+	})
+	if err != nil {
+		logger.Errorf("Error marshaling votes remaining: %v", err)
+		return
+	}
+
+	if !c.queue(data) {
+		logger.Errorf("Failed to send votes remaining to client")
+	}
+}
+
+// changesVoteBudgets reports whether an event can change any participant's
+// remaining votes, other than the voter's own vote which they are told about
+// directly
+func changesVoteBudgets(eventType string) bool {
+	switch eventType {
+	case models.EventVotingUpdated, models.EventTileDeleted, models.EventTileMoved,
+		models.EventColumnDeleted, models.EventGroupDissolved:
+		return true
+	}
+	return false
+}
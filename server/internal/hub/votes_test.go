@@ -0,0 +1,68 @@
+package hub
+
+import (
+	"testing"
+
+	"live-retro-server/internal/models"
+)
+
+func TestCastVote(t *testing.T) {
+	const me = "user_me"
+
+	tests := []struct {
+		name         string
+		voting       models.VotingSettings
+		voterIDs     []string
+		elsewhere    int // votes of mine on another tile
+		selfAuthored bool
+		remove       bool
+		want         []string
+		wantErr      error
+	}{
+		{name: "first vote", voting: models.DefaultVotingSettings(), voterIDs: []string{"other"}, want: []string{"other", me}},
+		{name: "voting again takes a single vote back", voting: models.DefaultVotingSettings(), voterIDs: []string{me, "other"}, want: []string{"other"}},
+		{name: "remove", voting: models.VotingSettings{MaxVotesPerTile: 3, AllowSelfVote: true}, voterIDs: []string{me, me}, remove: true, want: []string{me}},
+		{name: "remove without a vote", voting: models.DefaultVotingSettings(), voterIDs: []string{"other"}, remove: true, wantErr: errNoVoteToRemove},
+		{name: "stacked votes", voting: models.VotingSettings{MaxVotesPerTile: 2, AllowSelfVote: true}, voterIDs: []string{me}, want: []string{me, me}},
+		{name: "per-tile limit", voting: models.VotingSettings{MaxVotesPerTile: 2, AllowSelfVote: true}, voterIDs: []string{me, me}, wantErr: errTileVoteLimit},
+		{name: "self vote allowed", voting: models.DefaultVotingSettings(), selfAuthored: true, want: []string{me}},
+		{name: "unset settings allow self votes", selfAuthored: true, want: []string{me}},
+		{name: "self vote disallowed", voting: models.VotingSettings{MaxVotesPerTile: 1}, selfAuthored: true, wantErr: errSelfVote},
+		{name: "within the budget", voting: models.VotingSettings{VotesPerParticipant: 3, MaxVotesPerTile: 1, AllowSelfVote: true}, elsewhere: 2, want: []string{me}},
+		{name: "budget spent", voting: models.VotingSettings{VotesPerParticipant: 3, MaxVotesPerTile: 1, AllowSelfVote: true}, elsewhere: 3, wantErr: errVoteBudgetSpent},
+		{name: "removing ignores the budget", voting: models.VotingSettings{VotesPerParticipant: 3, MaxVotesPerTile: 2, AllowSelfVote: true}, voterIDs: []string{me}, elsewhere: 3, remove: true, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tile := &models.Tile{ID: "tile", VoterIDs: append([]string{}, tt.voterIDs...)}
+			other := &models.Tile{ID: "other"}
+			for i := 0; i < tt.elsewhere; i++ {
+				other.VoterIDs = append(other.VoterIDs, me)
+			}
+			board := &models.Board{
+				Columns: map[string]*models.Column{
+					"column": {ID: "column", Tiles: []*models.Tile{tile, other}},
+				},
+				Voting: tt.voting,
+			}
+			client := &Client{userID: me}
+
+			got, err := client.castVote(board, tile.VoterIDs, tt.selfAuthored, tt.remove)
+			if err != tt.wantErr {
+				t.Fatalf("castVote error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("castVote = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("castVote = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
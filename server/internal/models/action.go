@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// ActionItemStatus tracks an action item's progress
+type ActionItemStatus string
+
+const (
+	ActionOpen       ActionItemStatus = "open"
+	ActionInProgress ActionItemStatus = "in_progress"
+	ActionDone       ActionItemStatus = "done"
+)
+
+// DueDateLayout is the format of action item due dates
+const DueDateLayout = "2006-01-02"
+
+// ActionItem is a follow-up agreed during the retro
+type ActionItem struct {
+	ID              string           `json:"id"`
+	Title           string           `json:"title"`
+	Owner           string           `json:"owner"`
+	DueDate         string           `json:"dueDate,omitempty"` // YYYY-MM-DD
+	Status          ActionItemStatus `json:"status"`
+	SourceTileID    string           `json:"sourceTileId,omitempty"`    // tile it was promoted from
+	CarriedOverFrom string           `json:"carriedOverFrom,omitempty"` // board an open item came from
+	CreatedAt       time.Time        `json:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+}
+
+// IsValid reports whether s is a known status
+func (s ActionItemStatus) IsValid() bool {
+	switch s {
+	case ActionOpen, ActionInProgress, ActionDone:
+		return true
+	}
+	return false
+}
+
+// IsResolved reports whether no more work is expected on the item
+func (s ActionItemStatus) IsResolved() bool {
+	return s == ActionDone
+}
+
+// FindActionItem returns the action item with the given ID and its index
+func (b *Board) FindActionItem(actionID string) (int, *ActionItem) {
+	for i, item := range b.ActionItems {
+		if item.ID == actionID {
+			return i, item
+		}
+	}
+	return -1, nil
+}
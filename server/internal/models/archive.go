@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// ArchiveReason records why a board was archived
+type ArchiveReason string
+
+const (
+	ArchiveExpired ArchiveReason = "expired" // the board was about to expire
+	ArchiveClosed  ArchiveReason = "closed"  // an admin closed the retro
+)
+
+// ArchivedBoard is the final snapshot of a board kept after it has left the
+// board store. Only the last snapshot of each board is kept.
+type ArchivedBoard struct {
+	Board      *Board        `json:"board"`
+	ArchivedAt time.Time     `json:"archivedAt"`
+	Reason     ArchiveReason `json:"reason"`
+}
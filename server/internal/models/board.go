@@ -0,0 +1,281 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// OrderedColumns returns the board's columns sorted by Order, breaking ties by
+// ID so the result is stable even if Order values collide
+func (b *Board) OrderedColumns() []*Column {
+	columns := make([]*Column, 0, len(b.Columns))
+	for _, column := range b.Columns {
+		columns = append(columns, column)
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		if columns[i].Order != columns[j].Order {
+			return columns[i].Order < columns[j].Order
+		}
+		return columns[i].ID < columns[j].ID
+	})
+
+	return columns
+}
+
+// ColumnIDs returns the IDs of the board's columns in display order
+func (b *Board) ColumnIDs() []string {
+	ids := make([]string, 0, len(b.Columns))
+	for _, column := range b.OrderedColumns() {
+		ids = append(ids, column.ID)
+	}
+	return ids
+}
+
+// NormalizeColumnOrder renumbers the columns 0..n-1 in their current display
+// order. It runs after every column mutation so Order values never collide.
+func (b *Board) NormalizeColumnOrder() {
+	for i, column := range b.OrderedColumns() {
+		column.Order = i
+	}
+}
+
+// ReorderColumns sets the column order to match columnIDs, which must list
+// every column of the board exactly once
+func (b *Board) ReorderColumns(columnIDs []string) bool {
+	if len(columnIDs) != len(b.Columns) {
+		return false
+	}
+
+	seen := make(map[string]bool, len(columnIDs))
+	for _, id := range columnIDs {
+		if _, ok := b.Columns[id]; !ok || seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+
+	for i, id := range columnIDs {
+		b.Columns[id].Order = i
+	}
+	return true
+}
+
+// RemoveTile deletes the tile with the given ID and returns the column it was
+// in. The tile also leaves its group, and a group left empty is dissolved.
+func (b *Board) RemoveTile(tileID string) *Column {
+	column := b.detachTile(tileID)
+	if column != nil {
+		column.UngroupTile(tileID)
+	}
+	return column
+}
+
+// detachTile takes the tile out of its column's tile list only
+func (b *Board) detachTile(tileID string) *Column {
+	for _, column := range b.Columns {
+		for i, tile := range column.Tiles {
+			if tile.ID == tileID {
+				column.Tiles = append(column.Tiles[:i], column.Tiles[i+1:]...)
+				return column
+			}
+		}
+	}
+	return nil
+}
+
+// MoveTile moves a tile to position index of the target column, clamping the
+// index to the column's bounds. It returns the column the tile came from and
+// the index it ended up at.
+func (b *Board) MoveTile(tileID, columnID string, index int) (*Column, int, bool) {
+	target, ok := b.Columns[columnID]
+	if !ok {
+		return nil, 0, false
+	}
+
+	_, tile := b.FindTile(tileID)
+	if tile == nil {
+		return nil, 0, false
+	}
+	source := b.detachTile(tileID)
+	// Groups never span columns, so a tile leaving its column leaves its group
+	if source != target {
+		source.UngroupTile(tileID)
+	}
+
+	if index < 0 {
+		index = 0
+	}
+	if index > len(target.Tiles) {
+		index = len(target.Tiles)
+	}
+
+	target.Tiles = append(target.Tiles, nil)
+	copy(target.Tiles[index+1:], target.Tiles[index:])
+	target.Tiles[index] = tile
+
+	return source, index, true
+}
+
+// FindTile returns the tile with the given ID and the column holding it
+func (b *Board) FindTile(tileID string) (*Column, *Tile) {
+	for _, column := range b.Columns {
+		for _, tile := range column.Tiles {
+			if tile.ID == tileID {
+				return column, tile
+			}
+		}
+	}
+	return nil, nil
+}
+
+// FindGroup returns the group with the given ID and the column holding it
+func (b *Board) FindGroup(groupID string) (*Column, *Group) {
+	for _, column := range b.Columns {
+		for _, group := range column.Groups {
+			if group.ID == groupID {
+				return column, group
+			}
+		}
+	}
+	return nil, nil
+}
+
+// ParticipantCount returns how many participants added a tile, replied in a
+// thread or voted
+func (b *Board) ParticipantCount() int {
+	participants := make(map[string]bool)
+	add := func(ids ...string) {
+		for _, id := range ids {
+			if id != "" {
+				participants[id] = true
+			}
+		}
+	}
+
+	for _, column := range b.Columns {
+		for _, tile := range column.Tiles {
+			add(tile.AuthorID)
+			add(tile.VoterIDs...)
+			for _, thread := range tile.Threads {
+				add(thread.AuthorID)
+			}
+		}
+		for _, group := range column.Groups {
+			add(group.VoterIDs...)
+		}
+	}
+
+	return len(participants)
+}
+
+// ActivitySpan returns when the first and the last contribution to the board
+// were made: tiles, threads, groups, votes and action items. Changes to the
+// board's settings, such as extending it, do not count. Both are zero if
+// nothing was contributed.
+func (b *Board) ActivitySpan() (first, last time.Time) {
+	add := func(at time.Time) {
+		if at.IsZero() {
+			return
+		}
+		if first.IsZero() || at.Before(first) {
+			first = at
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+
+	for _, column := range b.Columns {
+		for _, tile := range column.Tiles {
+			add(tile.CreatedAt)
+			for _, thread := range tile.Threads {
+				add(thread.CreatedAt)
+			}
+		}
+		for _, group := range column.Groups {
+			add(group.CreatedAt)
+		}
+	}
+	for _, item := range b.ActionItems {
+		add(item.CreatedAt)
+		add(item.UpdatedAt)
+	}
+	if b.LastVoteAt != nil {
+		add(*b.LastVoteAt)
+	}
+
+	return first, last
+}
+
+// FindTile returns the tile with the given ID in this column
+func (c *Column) FindTile(tileID string) *Tile {
+	for _, tile := range c.Tiles {
+		if tile.ID == tileID {
+			return tile
+		}
+	}
+	return nil
+}
+
+// GroupOf returns the group containing the tile, if any
+func (c *Column) GroupOf(tileID string) *Group {
+	for _, group := range c.Groups {
+		for _, id := range group.TileIDs {
+			if id == tileID {
+				return group
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveGroup dissolves a group, leaving its tiles ungrouped in the column
+func (c *Column) RemoveGroup(groupID string) bool {
+	for i, group := range c.Groups {
+		if group.ID == groupID {
+			c.Groups = append(c.Groups[:i], c.Groups[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// UngroupTile takes the tile out of its group, dissolving the group if it is
+// left empty. It reports the group the tile was in, if any.
+func (c *Column) UngroupTile(tileID string) *Group {
+	group := c.GroupOf(tileID)
+	if group == nil {
+		return nil
+	}
+	group.TileIDs = RemoveID(group.TileIDs, tileID)
+	if len(group.TileIDs) == 0 {
+		c.RemoveGroup(group.ID)
+	}
+	c.RefreshGroupVotes()
+	return group
+}
+
+// RefreshGroupVotes recomputes each group's combined vote count. It must run
+// after any change to votes or group membership in the column.
+func (c *Column) RefreshGroupVotes() {
+	for _, group := range c.Groups {
+		total := len(group.VoterIDs)
+		for _, id := range group.TileIDs {
+			if tile := c.FindTile(id); tile != nil {
+				total += len(tile.VoterIDs)
+			}
+		}
+		group.TotalVotes = total
+	}
+}
+
+// RemoveID removes the first occurrence of id from ids, reusing its storage
+func RemoveID(ids []string, id string) []string {
+	for i, existing := range ids {
+		if existing == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
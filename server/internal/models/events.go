@@ -0,0 +1,291 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Server event types broadcast after a board mutation. Each carries only the
+// changed entity and the board version it produced as its sequence number.
+const (
+	EventTileCreated      = "server:tile:created"
+	EventTileUpdated      = "server:tile:updated"
+	EventTileDeleted      = "server:tile:deleted"
+	EventTileMoved        = "server:tile:moved"
+	EventTileRevealed     = "server:tile:revealed"
+	EventTilesRevealed    = "server:tiles:revealed"
+	EventVoteChanged      = "server:vote:changed"
+	EventColumnCreated    = "server:column:created"
+	EventColumnUpdated    = "server:column:updated"
+	EventColumnDeleted    = "server:column:deleted"
+	EventColumnsReordered = "server:columns:reordered"
+	EventThreadCreated    = "server:thread:created"
+	EventGroupCreated     = "server:group:created"
+	EventGroupUpdated     = "server:group:updated"
+	EventGroupDissolved   = "server:group:dissolved"
+	EventVotingUpdated    = "server:voting:updated"
+	EventPhaseChanged     = "server:phase:changed"
+	EventTimerUpdated     = "server:timer:updated"
+	EventTimerExpired     = "server:timer:expired"
+	EventActionCreated    = "server:action:created"
+	EventActionUpdated    = "server:action:updated"
+	EventActionDeleted    = "server:action:deleted"
+	EventRetentionUpdated = "server:board:retention_updated"
+	EventBoardClosed      = "server:board:closed"
+	EventBoardState       = "server:board:state_update"
+)
+
+type TileEventPayload struct {
+	ColumnID string `json:"columnId"`
+	Tile     *Tile  `json:"tile"`
+}
+
+type TileDeletedEventPayload struct {
+	ColumnID string `json:"columnId"`
+	TileID   string `json:"tileId"`
+}
+
+type TileMovedEventPayload struct {
+	TileID       string `json:"tileId"`
+	FromColumnID string `json:"fromColumnId"`
+	ToColumnID   string `json:"toColumnId"`
+	Index        int    `json:"index"`
+}
+
+type TilesEventPayload struct {
+	Tiles []TileEventPayload `json:"tiles"`
+}
+
+type VoteEventPayload struct {
+	TileID   string   `json:"tileId"`
+	VoterIDs []string `json:"voterIds"`
+
+	// Set when the tile belongs to a group, whose combined count changed too
+	GroupID    string `json:"groupId,omitempty"`
+	GroupVotes int    `json:"groupVotes,omitempty"`
+}
+
+// Column events carry the full column order, since creating, deleting or
+// reordering a column can renumber the others
+
+type ColumnEventPayload struct {
+	ColumnID    string   `json:"columnId"`
+	Title       string   `json:"title"`
+	Order       int      `json:"order"`
+	ColumnOrder []string `json:"columnOrder"`
+}
+
+type ColumnDeletedEventPayload struct {
+	ColumnID    string   `json:"columnId"`
+	ColumnOrder []string `json:"columnOrder"`
+}
+
+type ColumnsReorderedEventPayload struct {
+	ColumnOrder []string `json:"columnOrder"`
+}
+
+// VotesRemainingPayload is sent to a single participant whenever their vote
+// budget changes. Remaining is -1 when the board has no vote limit.
+type VotesRemainingPayload struct {
+	Limit     int `json:"limit"`
+	Used      int `json:"used"`
+	Remaining int `json:"remaining"`
+}
+
+type PhaseEventPayload struct {
+	Phase Phase `json:"phase"`
+}
+
+// TimerEventPayload carries the board's timer, nil once cancelled, with the
+// server clock so clients can show the same countdown
+type TimerEventPayload struct {
+	Timer      *Timer    `json:"timer"`
+	ServerTime time.Time `json:"serverTime"`
+}
+
+type TimerExpiredEventPayload struct {
+	Phase    Phase `json:"phase"`
+	Advanced bool  `json:"advanced"` // the phase was advanced automatically
+}
+
+type RetentionEventPayload struct {
+	TTLMinutes int        `json:"ttlMinutes,omitempty"`
+	KeepUntil  *time.Time `json:"keepUntil"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+}
+
+type BoardClosedEventPayload struct {
+	Archived bool `json:"archived"` // a final snapshot can be read from /api/archive
+}
+
+// ExpiringSoonPayload warns clients that the board will be deleted unless it
+// changes or is extended before ExpiresAt
+type ExpiringSoonPayload struct {
+	ExpiresAt  time.Time `json:"expiresAt"`
+	ServerTime time.Time `json:"serverTime"`
+}
+
+type ActionItemEventPayload struct {
+	ActionItem *ActionItem `json:"actionItem"`
+}
+
+type ActionItemDeletedEventPayload struct {
+	ActionID string `json:"actionId"`
+}
+
+type GroupEventPayload struct {
+	ColumnID string `json:"columnId"`
+	Group    *Group `json:"group"`
+
+	// TileVoterIDs holds the votes on the group's tiles, so a view can total
+	// only the votes its viewer may see. It is never sent to clients.
+	TileVoterIDs []string `json:"tileVoterIds,omitempty"`
+}
+
+// NewGroupEventPayload describes a group of the column after a change
+func NewGroupEventPayload(column *Column, group *Group) GroupEventPayload {
+	payload := GroupEventPayload{ColumnID: column.ID, Group: group}
+	for _, id := range group.TileIDs {
+		if tile := column.FindTile(id); tile != nil {
+			payload.TileVoterIDs = append(payload.TileVoterIDs, tile.VoterIDs...)
+		}
+	}
+	return payload
+}
+
+type GroupDissolvedEventPayload struct {
+	ColumnID string `json:"columnId"`
+	GroupID  string `json:"groupId"`
+}
+
+type ThreadEventPayload struct {
+	TileID string  `json:"tileId"`
+	Thread *Thread `json:"thread"`
+}
+
+// NewBoardEvent builds a sequenced event message for a mutation of board
+func NewBoardEvent(board *Board, eventType string, payload interface{}) WebSocketMessage {
+	return WebSocketMessage{
+		Type:        eventType,
+		Seq:         board.Version,
+		Payload:     payload,
+		VotesHidden: board.VotesHidden(),
+	}
+}
+
+// ProjectEvent returns the event as the viewer may see it. Payloads that embed
+// tiles, threads or votes are filtered the same way as ProjectBoard.
+func ProjectEvent(event WebSocketMessage, viewer Viewer) WebSocketMessage {
+	hideVotes := event.VotesHidden && viewer.Role != RoleAdmin
+
+	switch payload := event.Payload.(type) {
+	case TileEventPayload:
+		event.Payload = TileEventPayload{
+			ColumnID: payload.ColumnID,
+			Tile:     projectTile(payload.Tile, viewer, hideVotes),
+		}
+	case TilesEventPayload:
+		tiles := make([]TileEventPayload, 0, len(payload.Tiles))
+		for _, t := range payload.Tiles {
+			tiles = append(tiles, TileEventPayload{ColumnID: t.ColumnID, Tile: projectTile(t.Tile, viewer, hideVotes)})
+		}
+		event.Payload = TilesEventPayload{Tiles: tiles}
+	case VoteEventPayload:
+		if hideVotes {
+			event.Payload = VoteEventPayload{
+				TileID:   payload.TileID,
+				VoterIDs: ownVotes(payload.VoterIDs, viewer.UserID),
+				GroupID:  payload.GroupID,
+			}
+		}
+	case GroupEventPayload:
+		group := projectGroup(payload.Group, viewer, hideVotes)
+		if hideVotes {
+			// Count the viewer's own tile votes too, as a board snapshot does
+			group.TotalVotes += CountVotes(payload.TileVoterIDs, viewer.UserID)
+		}
+		event.Payload = GroupEventPayload{
+			ColumnID: payload.ColumnID,
+			Group:    group,
+		}
+	case ThreadEventPayload:
+		event.Payload = ThreadEventPayload{
+			TileID: payload.TileID,
+			Thread: projectThread(payload.Thread, viewer),
+		}
+	}
+	return event
+}
+
+// EventNeedsViewer reports whether an event's view depends on who receives it
+// rather than only on their role. Only hidden votes differ between viewers of
+// the same role; threads, for one, are filtered by role alone.
+func EventNeedsViewer(event WebSocketMessage, role Role) bool {
+	if !event.VotesHidden || role == RoleAdmin {
+		return false
+	}
+
+	switch event.Payload.(type) {
+	case TileEventPayload, TilesEventPayload, VoteEventPayload, GroupEventPayload:
+		return true
+	}
+	return false
+}
+
+// DecodeEventPayload restores the typed payload of an event received as JSON,
+// so it can be projected again. Events without a typed payload decode to a
+// generic value.
+func DecodeEventPayload(eventType string, raw json.RawMessage) (interface{}, error) {
+	switch eventType {
+	case EventTileCreated, EventTileUpdated, EventTileRevealed:
+		return decodePayload[TileEventPayload](raw)
+	case EventTileDeleted:
+		return decodePayload[TileDeletedEventPayload](raw)
+	case EventTileMoved:
+		return decodePayload[TileMovedEventPayload](raw)
+	case EventTilesRevealed:
+		return decodePayload[TilesEventPayload](raw)
+	case EventVoteChanged:
+		return decodePayload[VoteEventPayload](raw)
+	case EventColumnCreated, EventColumnUpdated:
+		return decodePayload[ColumnEventPayload](raw)
+	case EventColumnDeleted:
+		return decodePayload[ColumnDeletedEventPayload](raw)
+	case EventColumnsReordered:
+		return decodePayload[ColumnsReorderedEventPayload](raw)
+	case EventThreadCreated:
+		return decodePayload[ThreadEventPayload](raw)
+	case EventGroupCreated, EventGroupUpdated:
+		return decodePayload[GroupEventPayload](raw)
+	case EventGroupDissolved:
+		return decodePayload[GroupDissolvedEventPayload](raw)
+	case EventVotingUpdated:
+		return decodePayload[VotingSettings](raw)
+	case EventPhaseChanged:
+		return decodePayload[PhaseEventPayload](raw)
+	case EventTimerUpdated:
+		return decodePayload[TimerEventPayload](raw)
+	case EventTimerExpired:
+		return decodePayload[TimerExpiredEventPayload](raw)
+	case EventActionCreated, EventActionUpdated:
+		return decodePayload[ActionItemEventPayload](raw)
+	case EventActionDeleted:
+		return decodePayload[ActionItemDeletedEventPayload](raw)
+	case EventRetentionUpdated:
+		return decodePayload[RetentionEventPayload](raw)
+	case EventBoardClosed:
+		return decodePayload[BoardClosedEventPayload](raw)
+	default:
+		var payload interface{}
+		err := json.Unmarshal(raw, &payload)
+		return payload, err
+	}
+}
+
+func decodePayload[T any](raw json.RawMessage) (interface{}, error) {
+	var payload T
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
@@ -0,0 +1,111 @@
+package models
+
+import "testing"
+
+func TestProjectThreadEvent(t *testing.T) {
+	event := WebSocketMessage{
+		Type: EventThreadCreated,
+		Seq:  7,
+		Payload: ThreadEventPayload{
+			TileID: "tile",
+			Thread: &Thread{ID: "thread", Content: "Agreed", Author: "Cy", AuthorID: "user_cy"},
+		},
+		VotesHidden: true,
+	}
+
+	tests := []struct {
+		name         string
+		viewer       Viewer
+		wantAuthorID string
+	}{
+		{name: "admin", viewer: Viewer{Role: RoleAdmin, UserID: "user_admin"}, wantAuthorID: "user_cy"},
+		{name: "participant", viewer: Viewer{Role: RoleParticipant, UserID: "user_other"}},
+		{name: "author", viewer: Viewer{Role: RoleParticipant, UserID: "user_cy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projected := ProjectEvent(event, tt.viewer)
+
+			payload, ok := projected.Payload.(ThreadEventPayload)
+			if !ok {
+				t.Fatalf("payload is %T, want ThreadEventPayload", projected.Payload)
+			}
+			if payload.Thread.AuthorID != tt.wantAuthorID {
+				t.Errorf("author ID = %q, want %q", payload.Thread.AuthorID, tt.wantAuthorID)
+			}
+			if payload.TileID != "tile" || payload.Thread.Content != "Agreed" || payload.Thread.Author != "Cy" {
+				t.Errorf("projection changed the thread: %+v", payload)
+			}
+			if projected.Seq != event.Seq {
+				t.Errorf("seq = %d, want %d", projected.Seq, event.Seq)
+			}
+		})
+	}
+
+	if event.Payload.(ThreadEventPayload).Thread.AuthorID != "user_cy" {
+		t.Errorf("projecting modified the original thread")
+	}
+}
+
+func TestEventNeedsViewer(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     interface{}
+		votesHidden bool
+		role        Role
+		want        bool
+	}{
+		{name: "votes shown", payload: VoteEventPayload{}, role: RoleParticipant},
+		{name: "hidden votes for an admin", payload: VoteEventPayload{}, votesHidden: true, role: RoleAdmin},
+		{name: "hidden votes", payload: VoteEventPayload{}, votesHidden: true, role: RoleParticipant, want: true},
+		{name: "tile with hidden votes", payload: TileEventPayload{}, votesHidden: true, role: RoleParticipant, want: true},
+		{name: "group with hidden votes", payload: GroupEventPayload{}, votesHidden: true, role: RoleParticipant, want: true},
+		{name: "thread while votes are hidden", payload: ThreadEventPayload{}, votesHidden: true, role: RoleParticipant},
+		{name: "column while votes are hidden", payload: ColumnEventPayload{}, votesHidden: true, role: RoleParticipant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := WebSocketMessage{Payload: tt.payload, VotesHidden: tt.votesHidden}
+			if got := EventNeedsViewer(event, tt.role); got != tt.want {
+				t.Errorf("EventNeedsViewer = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectGroupEventTotal(t *testing.T) {
+	board := &Board{
+		ID:    "board",
+		Phase: PhaseVote,
+		Columns: map[string]*Column{
+			"column": {
+				ID: "column",
+				Tiles: []*Tile{
+					{ID: "a", VoterIDs: []string{"user_me", "user_other"}},
+					{ID: "b", VoterIDs: []string{"user_other"}},
+				},
+				Groups: []*Group{{ID: "group", TileIDs: []string{"a", "b"}, VoterIDs: []string{"user_me", "user_other"}}},
+			},
+		},
+	}
+	column := board.Columns["column"]
+	column.RefreshGroupVotes()
+
+	event := WebSocketMessage{
+		Type:        EventGroupUpdated,
+		Payload:     NewGroupEventPayload(column, column.Groups[0]),
+		VotesHidden: true,
+	}
+	viewer := Viewer{Role: RoleParticipant, UserID: "user_me"}
+
+	payload := ProjectEvent(event, viewer).Payload.(GroupEventPayload)
+	snapshot := ProjectBoard(board, viewer).Columns["column"].Groups[0]
+	if payload.Group.TotalVotes != snapshot.TotalVotes || payload.Group.TotalVotes != 2 {
+		t.Errorf("event total = %d, snapshot total = %d, want 2", payload.Group.TotalVotes, snapshot.TotalVotes)
+	}
+	if payload.TileVoterIDs != nil {
+		t.Errorf("projected event kept the tile votes: %v", payload.TileVoterIDs)
+	}
+}
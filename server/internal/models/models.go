@@ -6,23 +6,71 @@ import (
 
 type Board struct {
 	ID        string             `json:"id"`
-	AdminKey  string             `json:"adminKey"`
+	AdminKey  string             `json:"adminKey,omitempty"`
 	Columns   map[string]*Column `json:"columns"`
 	CreatedAt time.Time          `json:"createdAt"`
 	UpdatedAt time.Time          `json:"updatedAt"`
+	Version   int64              `json:"version"` // incremented on every save
+	Voting    VotingSettings     `json:"voting"`
+	Phase     Phase              `json:"phase"`
+	Phases    []Phase            `json:"phases,omitempty"` // phases this retro runs through; empty means all
+	Timer     *Timer             `json:"timer,omitempty"`
+
+	LastVoteAt *time.Time `json:"lastVoteAt,omitempty"` // when a vote was last cast or taken back
+
+	ActionItems []*ActionItem `json:"actionItems"`
+	TeamID      string        `json:"teamId,omitempty"`
+	TeamKey     string        `json:"teamKey,omitempty"` // proves membership of the team; admin view only
+
+	IcebreakerPrompt string `json:"icebreakerPrompt,omitempty"` // from the board's template
+
+	TTLMinutes int        `json:"ttlMinutes,omitempty"` // inactivity TTL; 0 uses the server default
+	KeepUntil  *time.Time `json:"keepUntil,omitempty"`  // kept at least until then, even if idle
+	ExpiresAt  time.Time  `json:"expiresAt"`            // set by the store on every save
+
+	// ColumnOrder lists column IDs in display order. It is derived from the
+	// columns' Order values and only filled in on board views.
+	ColumnOrder []string `json:"columnOrder,omitempty"`
+
+	// ServerTime is the server clock when a view was built, so clients can
+	// correct for skew when showing the timer. Only set on board views.
+	ServerTime *time.Time `json:"serverTime,omitempty"`
+}
+
+// VotingSettings configures dot-voting on a board. A participant may place up
+// to MaxVotesPerTile of their VotesPerParticipant votes on the same tile.
+type VotingSettings struct {
+	VotesPerParticipant int  `json:"votesPerParticipant"` // 0 means unlimited
+	MaxVotesPerTile     int  `json:"maxVotesPerTile"`
+	AllowSelfVote       bool `json:"allowSelfVote"`
 }
 
 type Column struct {
-	ID    string  `json:"id"`
-	Title string  `json:"title"`
-	Order int     `json:"order"`
-	Tiles []*Tile `json:"tiles"`
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Order  int      `json:"order"`
+	Color  string   `json:"color,omitempty"`
+	Tiles  []*Tile  `json:"tiles"`
+	Groups []*Group `json:"groups"`
+	Review bool     `json:"review,omitempty"` // shows the action items carried over from the last retro
+}
+
+// Group clusters related tiles of one column so they are discussed, voted on
+// and exported as a single item
+type Group struct {
+	ID         string    `json:"id"`
+	Title      string    `json:"title"`
+	TileIDs    []string  `json:"tileIds"`
+	VoterIDs   []string  `json:"voterIds"`
+	TotalVotes int       `json:"totalVotes"` // group votes plus member tile votes
+	CreatedAt  time.Time `json:"createdAt"`
 }
 
 type Tile struct {
 	ID        string    `json:"id"`
 	Content   string    `json:"content"`
 	Author    string    `json:"author"`
+	AuthorID  string    `json:"authorId,omitempty"` // participant ID of the creator
 	IsHidden  bool      `json:"isHidden"`
 	VoterIDs  []string  `json:"voterIds"`
 	Threads   []*Thread `json:"threads"`
@@ -33,12 +81,44 @@ type Thread struct {
 	ID        string    `json:"id"`
 	Content   string    `json:"content"`
 	Author    string    `json:"author"`
+	AuthorID  string    `json:"authorId,omitempty"` // participant ID of the creator
 	CreatedAt time.Time `json:"createdAt"`
 }
 
 type WebSocketMessage struct {
 	Type    string      `json:"type"`
+	Seq     int64       `json:"seq,omitempty"` // board version after the change, for board events
 	Payload interface{} `json:"payload"`
+
+	// VotesHidden records that the board kept vote counts from participants
+	// when the event happened. It is never sent to clients.
+	VotesHidden bool `json:"-"`
+}
+
+// CreateBoardRequest is the optional body of POST /api/boards. Columns,
+// Voting and Phases override the chosen template's defaults.
+type CreateBoardRequest struct {
+	TeamID           string           `json:"teamId"`           // carry over the team's open action items
+	TeamKey          string           `json:"teamKey"`          // required to join an existing team
+	PreviousBoardID  string           `json:"previousBoardId"`  // carry over this board's open action items
+	PreviousAdminKey string           `json:"previousAdminKey"` // admin key of the previous board
+	Template         string           `json:"template"`         // template ID, "custom" or empty for the default
+	Columns          []TemplateColumn `json:"columns,omitempty"`
+	Voting           *VotingSettings  `json:"voting,omitempty"`
+	Phases           []Phase          `json:"phases,omitempty"`
+	TTLMinutes       int              `json:"ttlMinutes,omitempty"` // within the server's limits
+}
+
+// SaveTemplateRequest is the body of POST /api/templates and
+// PUT /api/templates/{id}. Voting and Phases default to the built-in ones.
+type SaveTemplateRequest struct {
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Columns          []TemplateColumn `json:"columns"`
+	Voting           *VotingSettings  `json:"voting,omitempty"`
+	Phases           []Phase          `json:"phases,omitempty"`
+	IcebreakerPrompt string           `json:"icebreakerPrompt"`
+	TeamID           string           `json:"teamId"`
 }
 
 type CreateTilePayload struct {
@@ -47,12 +127,107 @@ type CreateTilePayload struct {
 	Author   string `json:"author,omitempty"`
 }
 
+type UpdateTilePayload struct {
+	TileID  string `json:"tileId"`
+	Content string `json:"content"`
+}
+
+type DeleteTilePayload struct {
+	TileID string `json:"tileId"`
+}
+
+type MoveTilePayload struct {
+	TileID   string `json:"tileId"`
+	ColumnID string `json:"columnId"` // target column
+	Index    int    `json:"index"`    // position in the target column
+}
+
 type RevealTilePayload struct {
 	TileID string `json:"tileId"`
 }
 
 type VoteTilePayload struct {
 	TileID string `json:"tileId"`
+	Remove bool   `json:"remove,omitempty"` // take back one vote instead of adding one
+}
+
+type CreateGroupPayload struct {
+	ColumnID string   `json:"columnId"`
+	Title    string   `json:"title"`
+	TileIDs  []string `json:"tileIds"`
+}
+
+type RenameGroupPayload struct {
+	GroupID string `json:"groupId"`
+	Title   string `json:"title"`
+}
+
+type GroupTilePayload struct {
+	GroupID string `json:"groupId"`
+	TileID  string `json:"tileId"`
+}
+
+type GroupPayload struct {
+	GroupID string `json:"groupId"`
+}
+
+type VoteGroupPayload struct {
+	GroupID string `json:"groupId"`
+	Remove  bool   `json:"remove,omitempty"` // take back one vote instead of adding one
+}
+
+type AdvancePhasePayload struct {
+	Phase Phase `json:"phase,omitempty"` // empty advances to the next phase
+}
+
+type StartTimerPayload struct {
+	DurationSeconds int  `json:"durationSeconds"` // 0 resumes a paused timer
+	AutoAdvance     bool `json:"autoAdvance"`
+}
+
+type ExtendTimerPayload struct {
+	Seconds int `json:"seconds"`
+}
+
+type CreateActionItemPayload struct {
+	Title   string           `json:"title"`
+	Owner   string           `json:"owner"`
+	DueDate string           `json:"dueDate"`
+	Status  ActionItemStatus `json:"status"` // defaults to open
+}
+
+// UpdateActionItemPayload changes only the fields that are present
+type UpdateActionItemPayload struct {
+	ActionID string            `json:"actionId"`
+	Title    *string           `json:"title,omitempty"`
+	Owner    *string           `json:"owner,omitempty"`
+	DueDate  *string           `json:"dueDate,omitempty"`
+	Status   *ActionItemStatus `json:"status,omitempty"`
+}
+
+type DeleteActionItemPayload struct {
+	ActionID string `json:"actionId"`
+}
+
+type PromoteTilePayload struct {
+	TileID  string `json:"tileId"`
+	Owner   string `json:"owner"`
+	DueDate string `json:"dueDate"`
+}
+
+// SaveBoardTemplatePayload saves the board's current layout as a template
+type SaveBoardTemplatePayload struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	IcebreakerPrompt string `json:"icebreakerPrompt"`
+}
+
+type ExtendBoardPayload struct {
+	Minutes int `json:"minutes"`
+}
+
+type KeepBoardUntilPayload struct {
+	KeepUntil *time.Time `json:"keepUntil"` // null returns the board to its TTL
 }
 
 type CreateColumnPayload struct {
@@ -64,6 +239,10 @@ type UpdateColumnPayload struct {
 	Title    string `json:"title"`
 }
 
+type ReorderColumnsPayload struct {
+	ColumnIDs []string `json:"columnIds"`
+}
+
 type DeleteColumnPayload struct {
 	ColumnID string `json:"columnId"`
 }
@@ -76,4 +255,8 @@ type CreateThreadPayload struct {
 	TileID  string `json:"tileId"`
 	Content string `json:"content"`
 	Author  string `json:"author,omitempty"`
-}
\ No newline at end of file
+}
+
+type SyncResumePayload struct {
+	LastSeq int64 `json:"lastSeq"`
+}
@@ -0,0 +1,81 @@
+package models
+
+// Phase is the stage of the retrospective a board is in. Phases decide which
+// operations participants may perform.
+type Phase string
+
+const (
+	PhaseBrainstorm  Phase = "brainstorm"
+	PhaseGroup       Phase = "group"
+	PhaseVote        Phase = "vote"
+	PhaseDiscuss     Phase = "discuss"
+	PhaseActionItems Phase = "action_items"
+)
+
+// Phases lists every phase in facilitation order
+var Phases = []Phase{PhaseBrainstorm, PhaseGroup, PhaseVote, PhaseDiscuss, PhaseActionItems}
+
+// IsValid reports whether p is a known phase
+func (p Phase) IsValid() bool {
+	return p.index() >= 0
+}
+
+// Before reports whether p comes earlier in the retro than other
+func (p Phase) Before(other Phase) bool {
+	return p.index() < other.index()
+}
+
+func (p Phase) index() int {
+	for i, phase := range Phases {
+		if phase == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// EnabledPhases returns the phases the board runs through, in order. Boards
+// created without a phase configuration run through all of them.
+func (b *Board) EnabledPhases() []Phase {
+	if len(b.Phases) == 0 {
+		return Phases
+	}
+	return b.Phases
+}
+
+// PhaseEnabled reports whether the board's retro includes phase p
+func (b *Board) PhaseEnabled(p Phase) bool {
+	for _, phase := range b.EnabledPhases() {
+		if phase == p {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentPhase returns the board's phase. Boards saved before phases existed
+// start in their first phase.
+func (b *Board) CurrentPhase() Phase {
+	if !b.Phase.IsValid() {
+		return b.EnabledPhases()[0]
+	}
+	return b.Phase
+}
+
+// NextPhase returns the enabled phase after the current one, or the current
+// phase if the retro has no further phases
+func (b *Board) NextPhase() Phase {
+	current := b.CurrentPhase()
+	for _, phase := range b.EnabledPhases() {
+		if current.Before(phase) {
+			return phase
+		}
+	}
+	return current
+}
+
+// VotesHidden reports whether vote counts are kept from participants. They
+// stay hidden until the vote phase closes so early votes cannot sway others.
+func (b *Board) VotesHidden() bool {
+	return b.CurrentPhase().Before(PhaseDiscuss)
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Retention bounds how long boards are kept. A board expires once it has gone
+// unchanged for its TTL, unless an admin asked to keep it until a later time.
+type Retention struct {
+	DefaultTTL time.Duration // for boards created without their own TTL
+	MinTTL     time.Duration
+	MaxTTL     time.Duration // also the furthest ahead a board can be kept
+}
+
+// DefaultRetention keeps boards for 30 minutes of inactivity and lets them be
+// kept for up to a week
+func DefaultRetention() Retention {
+	return Retention{
+		DefaultTTL: 30 * time.Minute,
+		MinTTL:     5 * time.Minute,
+		MaxTTL:     7 * 24 * time.Hour,
+	}
+}
+
+// TTLFor returns how long the board survives without changes
+func (r Retention) TTLFor(board *Board) time.Duration {
+	if board.TTLMinutes > 0 {
+		return time.Duration(board.TTLMinutes) * time.Minute
+	}
+	return r.DefaultTTL
+}
+
+// ExpiryOf returns when the board expires if it is saved at now
+func (r Retention) ExpiryOf(board *Board, now time.Time) time.Time {
+	expiresAt := now.Add(r.TTLFor(board))
+	if board.KeepUntil != nil && board.KeepUntil.After(expiresAt) {
+		return *board.KeepUntil
+	}
+	return expiresAt
+}
+
+// ValidateTTLMinutes checks a TTL requested for a new board; 0 selects the
+// default
+func (r Retention) ValidateTTLMinutes(minutes int) error {
+	if minutes == 0 {
+		return nil
+	}
+
+	ttl := time.Duration(minutes) * time.Minute
+	if ttl < r.MinTTL || ttl > r.MaxTTL {
+		return fmt.Errorf("board TTL must be between %d and %d minutes", int(r.MinTTL.Minutes()), int(r.MaxTTL.Minutes()))
+	}
+
+	return nil
+}
+
+// ValidateKeepUntil checks a time an admin wants to keep the board until
+func (r Retention) ValidateKeepUntil(keepUntil, now time.Time) error {
+	if !keepUntil.After(now) {
+		return fmt.Errorf("a board can only be kept until a time in the future")
+	}
+
+	if keepUntil.After(now.Add(r.MaxTTL)) {
+		return fmt.Errorf("a board can be kept for at most %d hours", int(r.MaxTTL.Hours()))
+	}
+
+	return nil
+}
+
+// ExpiryWarningLead returns how long before the board expires its clients are
+// warned. Short TTLs get a proportionally shorter warning so it is not sent
+// right after every change.
+func (r Retention) ExpiryWarningLead(board *Board) time.Duration {
+	lead := 5 * time.Minute
+	if half := r.TTLFor(board) / 2; half < lead {
+		lead = half
+	}
+	return lead
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// Team links a team's consecutive retros so unresolved action items carry
+// over from one board to the next, even after the old board has expired
+type Team struct {
+	ID          string        `json:"id"`
+	Key         string        `json:"key"` // secret needed to start a board for the team
+	LastBoardID string        `json:"lastBoardId"`
+	ActionItems []*ActionItem `json:"actionItems"` // unresolved items of the last board
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// TeamSnapshot records the board's unresolved action items for its team
+func (b *Board) TeamSnapshot() *Team {
+	return &Team{
+		ID:          b.TeamID,
+		Key:         b.TeamKey,
+		LastBoardID: b.ID,
+		ActionItems: UnresolvedActionItems(b.ActionItems, b.ID),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// ReviewColumnTitle names the column that holds carried-over action items
+const ReviewColumnTitle = "Last retro's actions"
+
+// UnresolvedActionItems returns copies of the items still needing work,
+// marked as carried over from the given board unless they already were
+func UnresolvedActionItems(items []*ActionItem, fromBoardID string) []*ActionItem {
+	unresolved := make([]*ActionItem, 0, len(items))
+	for _, item := range items {
+		if item.Status.IsResolved() {
+			continue
+		}
+		itemCopy := *item
+		if itemCopy.CarriedOverFrom == "" {
+			itemCopy.CarriedOverFrom = fromBoardID
+		}
+		unresolved = append(unresolved, &itemCopy)
+	}
+	return unresolved
+}
@@ -0,0 +1,221 @@
+package models
+
+import (
+	"fmt"
+)
+
+// TemplateCustom selects no preset; the request supplies the columns itself
+const TemplateCustom = "custom"
+
+// DefaultTemplateID is used when a board is created without a template
+const DefaultTemplateID = "classic"
+
+// Template describes the columns and settings a new board starts with.
+// Besides the built-in formats, teams can store their own; the text of stored
+// templates is sanitized like any other board content.
+type Template struct {
+	ID               string           `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description,omitempty"`
+	Columns          []TemplateColumn `json:"columns"`
+	Voting           VotingSettings   `json:"voting"`
+	Phases           []Phase          `json:"phases"` // the phases the retro runs through
+	IcebreakerPrompt string           `json:"icebreakerPrompt,omitempty"`
+	TeamID           string           `json:"teamId,omitempty"`  // team that owns a stored template
+	BuiltIn          bool             `json:"builtIn,omitempty"` // shipped with the server, read-only
+	EditKey          string           `json:"editKey,omitempty"` // unlocks changes to a stored template
+}
+
+type TemplateColumn struct {
+	Title string `json:"title"`
+	Color string `json:"color,omitempty"` // #rgb or #rrggbb
+}
+
+// BuiltinTemplates lists the retro formats every server offers
+var BuiltinTemplates = []*Template{
+	{
+		ID:          DefaultTemplateID,
+		Name:        "Classic",
+		Description: "What went well, what could be improved and what to do next",
+		Columns: []TemplateColumn{
+			{Title: "What went well?", Color: "#22c55e"},
+			{Title: "What could be improved?", Color: "#f97316"},
+			{Title: "Action items", Color: "#3b82f6"},
+		},
+		Voting: DefaultVotingSettings(),
+		Phases:  Phases,
+		BuiltIn: true,
+	},
+	{
+		ID:          "start-stop-continue",
+		Name:        "Start, Stop, Continue",
+		Description: "Decide which practices to adopt, drop and keep",
+		Columns: []TemplateColumn{
+			{Title: "Start", Color: "#22c55e"},
+			{Title: "Stop", Color: "#ef4444"},
+			{Title: "Continue", Color: "#3b82f6"},
+		},
+		Voting: VotingSettings{VotesPerParticipant: 5, MaxVotesPerTile: 2, AllowSelfVote: true},
+		Phases:  Phases,
+		BuiltIn: true,
+	},
+	{
+		ID:          "mad-sad-glad",
+		Name:        "Mad, Sad, Glad",
+		Description: "Share how the sprint felt",
+		Columns: []TemplateColumn{
+			{Title: "Mad", Color: "#ef4444"},
+			{Title: "Sad", Color: "#6366f1"},
+			{Title: "Glad", Color: "#22c55e"},
+		},
+		Voting: VotingSettings{VotesPerParticipant: 3, MaxVotesPerTile: 1, AllowSelfVote: false},
+		Phases:  Phases,
+		BuiltIn: true,
+	},
+	{
+		ID:          "4ls",
+		Name:        "4Ls",
+		Description: "What the team liked, learned, lacked and longed for",
+		Columns: []TemplateColumn{
+			{Title: "Liked", Color: "#22c55e"},
+			{Title: "Learned", Color: "#3b82f6"},
+			{Title: "Lacked", Color: "#f97316"},
+			{Title: "Longed for", Color: "#a855f7"},
+		},
+		Voting: VotingSettings{VotesPerParticipant: 5, MaxVotesPerTile: 2, AllowSelfVote: true},
+		Phases:  Phases,
+		BuiltIn: true,
+	},
+	{
+		ID:          "starfish",
+		Name:        "Starfish",
+		Description: "Scale practices up or down instead of only keeping or dropping them",
+		Columns: []TemplateColumn{
+			{Title: "Keep doing", Color: "#22c55e"},
+			{Title: "Less of", Color: "#eab308"},
+			{Title: "More of", Color: "#3b82f6"},
+			{Title: "Stop doing", Color: "#ef4444"},
+			{Title: "Start doing", Color: "#a855f7"},
+		},
+		Voting: VotingSettings{VotesPerParticipant: 6, MaxVotesPerTile: 2, AllowSelfVote: true},
+		Phases:  Phases,
+		BuiltIn: true,
+	},
+	{
+		ID:          "sailboat",
+		Name:        "Sailboat",
+		Description: "What pushes the team forward, holds it back and lies ahead",
+		Columns: []TemplateColumn{
+			{Title: "Wind", Color: "#22c55e"},
+			{Title: "Anchors", Color: "#ef4444"},
+			{Title: "Rocks", Color: "#f97316"},
+			{Title: "Island", Color: "#3b82f6"},
+		},
+		Voting: VotingSettings{VotesPerParticipant: 5, MaxVotesPerTile: 2, AllowSelfVote: true},
+		Phases:  Phases,
+		BuiltIn: true,
+	},
+}
+
+// LookupTemplate returns a copy of the built-in template with the given ID
+func LookupTemplate(id string) (*Template, bool) {
+	for _, template := range BuiltinTemplates {
+		if template.ID == id {
+			return template.Copy(), true
+		}
+	}
+	return nil, false
+}
+
+// Copy returns a deep copy that can be changed without touching the original
+func (t *Template) Copy() *Template {
+	template := *t
+	template.Columns = append([]TemplateColumn{}, t.Columns...)
+	template.Phases = append([]Phase{}, t.Phases...)
+	return &template
+}
+
+// Public returns a copy of the template without its edit key
+func (t *Template) Public() *Template {
+	template := t.Copy()
+	template.EditKey = ""
+	return template
+}
+
+// NewTemplate builds a stored template from a request, filling in the default
+// voting settings and phases when the request leaves them out
+func NewTemplate(request *SaveTemplateRequest) *Template {
+	template := &Template{
+		Name:             request.Name,
+		Description:      request.Description,
+		Columns:          append([]TemplateColumn{}, request.Columns...),
+		Voting:           DefaultVotingSettings(),
+		Phases:           append([]Phase{}, Phases...),
+		IcebreakerPrompt: request.IcebreakerPrompt,
+		TeamID:           request.TeamID,
+	}
+	if request.Voting != nil {
+		template.Voting = *request.Voting
+	}
+	if len(request.Phases) > 0 {
+		template.Phases = append([]Phase{}, request.Phases...)
+	}
+	return template
+}
+
+// TemplateFromBoard captures the board's columns, in display order, along
+// with its voting settings and phases
+func TemplateFromBoard(board *Board, payload *SaveBoardTemplatePayload) *Template {
+	template := &Template{
+		Name:             payload.Name,
+		Description:      payload.Description,
+		Columns:          make([]TemplateColumn, 0, len(board.Columns)),
+		Voting:           board.VotingSettings(),
+		Phases:           append([]Phase{}, board.EnabledPhases()...),
+		IcebreakerPrompt: payload.IcebreakerPrompt,
+		TeamID:           board.TeamID,
+	}
+	for _, column := range board.OrderedColumns() {
+		template.Columns = append(template.Columns, TemplateColumn{
+			Title: column.Title,
+			Color: column.Color,
+		})
+	}
+	return template
+}
+
+// ResolveTemplate builds the template a board is created from: base, or an
+// empty custom template if base is nil, with any columns, voting settings and
+// phases given in the request taking precedence. A custom board must list its
+// columns.
+func ResolveTemplate(base *Template, request *CreateBoardRequest) (*Template, error) {
+	var template *Template
+	if base == nil {
+		if len(request.Columns) == 0 {
+			return nil, fmt.Errorf("a custom template needs at least one column")
+		}
+		template = &Template{
+			ID:     TemplateCustom,
+			Name:   "Custom",
+			Voting: DefaultVotingSettings(),
+			Phases: append([]Phase{}, Phases...),
+		}
+	} else {
+		template = base.Copy()
+	}
+
+	if len(request.Columns) > 0 {
+		template.Columns = append([]TemplateColumn{}, request.Columns...)
+	}
+	if request.Voting != nil {
+		template.Voting = *request.Voting
+	}
+	if len(request.Phases) > 0 {
+		template.Phases = append([]Phase{}, request.Phases...)
+	}
+
+	if err := ValidateTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+// Timer is a board's countdown. While running its Deadline is authoritative;
+// while paused the time left is kept in RemainingMs.
+type Timer struct {
+	Running     bool      `json:"running"`
+	Deadline    time.Time `json:"deadline"`    // when the timer runs out, while running
+	RemainingMs int64     `json:"remainingMs"` // time left, while paused
+	DurationMs  int64     `json:"durationMs"`  // total length including extensions
+	AutoAdvance bool      `json:"autoAdvance"` // advance the phase when it runs out
+}
+
+// NewTimer starts a timer running for duration from now
+func NewTimer(duration time.Duration, autoAdvance bool, now time.Time) *Timer {
+	return &Timer{
+		Running:     true,
+		Deadline:    now.Add(duration),
+		DurationMs:  duration.Milliseconds(),
+		AutoAdvance: autoAdvance,
+	}
+}
+
+// Remaining returns how much time is left on the timer at now
+func (t *Timer) Remaining(now time.Time) time.Duration {
+	if !t.Running {
+		return time.Duration(t.RemainingMs) * time.Millisecond
+	}
+	if remaining := t.Deadline.Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Expired reports whether a running timer has run out at now
+func (t *Timer) Expired(now time.Time) bool {
+	return t.Running && !now.Before(t.Deadline)
+}
+
+// Pause stops the countdown, keeping the time left
+func (t *Timer) Pause(now time.Time) {
+	t.RemainingMs = t.Remaining(now).Milliseconds()
+	t.Running = false
+	t.Deadline = time.Time{}
+}
+
+// Resume restarts a paused countdown from where it stopped
+func (t *Timer) Resume(now time.Time) {
+	t.Deadline = now.Add(time.Duration(t.RemainingMs) * time.Millisecond)
+	t.RemainingMs = 0
+	t.Running = true
+}
+
+// Extend adds extra time to the timer, running or paused
+func (t *Timer) Extend(extra time.Duration) {
+	if t.Running {
+		t.Deadline = t.Deadline.Add(extra)
+	} else {
+		t.RemainingMs += extra.Milliseconds()
+	}
+	t.DurationMs += extra.Milliseconds()
+}
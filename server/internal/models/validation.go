@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"html"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -12,6 +13,17 @@ const (
 	MaxColumnTitleLength   = 100
 	MaxAuthorNameLength    = 50
 	MaxThreadContentLength = 500
+	MaxGroupTitleLength    = 100
+	MaxVotesPerParticipant = 100
+	MaxVotesPerTile        = 10
+	MaxTimerSeconds        = 2 * 60 * 60
+	MaxActionTitleLength   = 200
+	MaxTeamIDLength        = 64
+	MaxTemplateColumns     = 10
+	MaxTemplateNameLength  = 100
+	MaxTemplateTextLength  = 500
+	MaxTeamTemplates       = 50
+	MaxImportedTiles       = 500
 )
 
 // isValidUTF8 checks if the string is valid UTF-8
@@ -49,6 +61,172 @@ func ValidateCreateTilePayload(payload *CreateTilePayload) error {
 	return nil
 }
 
+func ValidateUpdateTilePayload(payload *UpdateTilePayload) error {
+	if payload.TileID == "" {
+		return fmt.Errorf("tile ID is required")
+	}
+
+	if strings.TrimSpace(payload.Content) == "" {
+		return fmt.Errorf("tile content is required")
+	}
+
+	// Validate UTF-8 encoding
+	if !isValidUTF8(payload.Content) {
+		return fmt.Errorf("tile content contains invalid UTF-8 characters")
+	}
+
+	// Use rune count for proper UTF-8 character counting (includes emojis)
+	if utf8.RuneCountInString(payload.Content) > MaxTileContentLength {
+		return fmt.Errorf("tile content exceeds maximum length of %d characters", MaxTileContentLength)
+	}
+
+	return nil
+}
+
+func ValidateDeleteTilePayload(payload *DeleteTilePayload) error {
+	if payload.TileID == "" {
+		return fmt.Errorf("tile ID is required")
+	}
+
+	return nil
+}
+
+func ValidateMoveTilePayload(payload *MoveTilePayload) error {
+	if payload.TileID == "" {
+		return fmt.Errorf("tile ID is required")
+	}
+
+	if payload.ColumnID == "" {
+		return fmt.Errorf("column ID is required")
+	}
+
+	if payload.Index < 0 {
+		return fmt.Errorf("index must not be negative")
+	}
+
+	return nil
+}
+
+func validateGroupTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return fmt.Errorf("group title is required")
+	}
+
+	// Validate UTF-8 encoding
+	if !isValidUTF8(title) {
+		return fmt.Errorf("group title contains invalid UTF-8 characters")
+	}
+
+	// Use rune count for proper UTF-8 character counting (includes emojis)
+	if utf8.RuneCountInString(title) > MaxGroupTitleLength {
+		return fmt.Errorf("group title exceeds maximum length of %d characters", MaxGroupTitleLength)
+	}
+
+	return nil
+}
+
+func ValidateCreateGroupPayload(payload *CreateGroupPayload) error {
+	if payload.ColumnID == "" {
+		return fmt.Errorf("column ID is required")
+	}
+
+	if len(payload.TileIDs) == 0 {
+		return fmt.Errorf("at least one tile is required to form a group")
+	}
+
+	for _, id := range payload.TileIDs {
+		if id == "" {
+			return fmt.Errorf("tile IDs must not be empty")
+		}
+	}
+
+	return validateGroupTitle(payload.Title)
+}
+
+func ValidateRenameGroupPayload(payload *RenameGroupPayload) error {
+	if payload.GroupID == "" {
+		return fmt.Errorf("group ID is required")
+	}
+
+	return validateGroupTitle(payload.Title)
+}
+
+func ValidateGroupTilePayload(payload *GroupTilePayload) error {
+	if payload.GroupID == "" {
+		return fmt.Errorf("group ID is required")
+	}
+
+	if payload.TileID == "" {
+		return fmt.Errorf("tile ID is required")
+	}
+
+	return nil
+}
+
+func ValidateGroupPayload(payload *GroupPayload) error {
+	if payload.GroupID == "" {
+		return fmt.Errorf("group ID is required")
+	}
+
+	return nil
+}
+
+func ValidateVoteGroupPayload(payload *VoteGroupPayload) error {
+	if payload.GroupID == "" {
+		return fmt.Errorf("group ID is required")
+	}
+
+	return nil
+}
+
+func ValidateVotingSettings(settings *VotingSettings) error {
+	if settings.VotesPerParticipant < 0 || settings.VotesPerParticipant > MaxVotesPerParticipant {
+		return fmt.Errorf("votes per participant must be between 0 and %d", MaxVotesPerParticipant)
+	}
+
+	if settings.MaxVotesPerTile < 1 || settings.MaxVotesPerTile > MaxVotesPerTile {
+		return fmt.Errorf("max votes per tile must be between 1 and %d", MaxVotesPerTile)
+	}
+
+	if settings.VotesPerParticipant > 0 && settings.MaxVotesPerTile > settings.VotesPerParticipant {
+		return fmt.Errorf("max votes per tile cannot exceed votes per participant")
+	}
+
+	return nil
+}
+
+func ValidateAdvancePhasePayload(payload *AdvancePhasePayload) error {
+	if payload.Phase != "" && !payload.Phase.IsValid() {
+		return fmt.Errorf("unknown phase: %s", payload.Phase)
+	}
+
+	return nil
+}
+
+func ValidateStartTimerPayload(payload *StartTimerPayload) error {
+	if payload.DurationSeconds < 0 || payload.DurationSeconds > MaxTimerSeconds {
+		return fmt.Errorf("timer duration must be between 1 and %d seconds", MaxTimerSeconds)
+	}
+
+	return nil
+}
+
+func ValidateExtendTimerPayload(payload *ExtendTimerPayload) error {
+	if payload.Seconds < 1 || payload.Seconds > MaxTimerSeconds {
+		return fmt.Errorf("timer extension must be between 1 and %d seconds", MaxTimerSeconds)
+	}
+
+	return nil
+}
+
+func ValidateExtendBoardPayload(payload *ExtendBoardPayload) error {
+	if payload.Minutes < 1 {
+		return fmt.Errorf("extension must be at least 1 minute")
+	}
+
+	return nil
+}
+
 func ValidateCreateColumnPayload(payload *CreateColumnPayload) error {
 	if strings.TrimSpace(payload.Title) == "" {
 		return fmt.Errorf("column title is required")
@@ -89,6 +267,20 @@ func ValidateUpdateColumnPayload(payload *UpdateColumnPayload) error {
 	return nil
 }
 
+func ValidateReorderColumnsPayload(payload *ReorderColumnsPayload) error {
+	if len(payload.ColumnIDs) == 0 {
+		return fmt.Errorf("column IDs are required")
+	}
+
+	for _, id := range payload.ColumnIDs {
+		if id == "" {
+			return fmt.Errorf("column IDs must not be empty")
+		}
+	}
+
+	return nil
+}
+
 func ValidateDeleteColumnPayload(payload *DeleteColumnPayload) error {
 	if payload.ColumnID == "" {
 		return fmt.Errorf("column ID is required")
@@ -127,6 +319,297 @@ func ValidateCreateThreadPayload(payload *CreateThreadPayload) error {
 	return nil
 }
 
+func validateActionTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return fmt.Errorf("action item title is required")
+	}
+
+	// Validate UTF-8 encoding
+	if !isValidUTF8(title) {
+		return fmt.Errorf("action item title contains invalid UTF-8 characters")
+	}
+
+	// Use rune count for proper UTF-8 character counting (includes emojis)
+	if utf8.RuneCountInString(title) > MaxActionTitleLength {
+		return fmt.Errorf("action item title exceeds maximum length of %d characters", MaxActionTitleLength)
+	}
+
+	return nil
+}
+
+func validateActionOwner(owner string) error {
+	if !isValidUTF8(owner) {
+		return fmt.Errorf("owner name contains invalid UTF-8 characters")
+	}
+
+	if utf8.RuneCountInString(owner) > MaxAuthorNameLength {
+		return fmt.Errorf("owner name exceeds maximum length of %d characters", MaxAuthorNameLength)
+	}
+
+	return nil
+}
+
+func validateDueDate(dueDate string) error {
+	if dueDate == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(DueDateLayout, dueDate); err != nil {
+		return fmt.Errorf("due date must be formatted as YYYY-MM-DD")
+	}
+
+	return nil
+}
+
+func ValidateCreateActionItemPayload(payload *CreateActionItemPayload) error {
+	if err := validateActionTitle(payload.Title); err != nil {
+		return err
+	}
+
+	if err := validateActionOwner(payload.Owner); err != nil {
+		return err
+	}
+
+	if err := validateDueDate(payload.DueDate); err != nil {
+		return err
+	}
+
+	if payload.Status != "" && !payload.Status.IsValid() {
+		return fmt.Errorf("unknown action item status: %s", payload.Status)
+	}
+
+	return nil
+}
+
+func ValidateUpdateActionItemPayload(payload *UpdateActionItemPayload) error {
+	if payload.ActionID == "" {
+		return fmt.Errorf("action item ID is required")
+	}
+
+	if payload.Title != nil {
+		if err := validateActionTitle(*payload.Title); err != nil {
+			return err
+		}
+	}
+
+	if payload.Owner != nil {
+		if err := validateActionOwner(*payload.Owner); err != nil {
+			return err
+		}
+	}
+
+	if payload.DueDate != nil {
+		if err := validateDueDate(*payload.DueDate); err != nil {
+			return err
+		}
+	}
+
+	if payload.Status != nil && !payload.Status.IsValid() {
+		return fmt.Errorf("unknown action item status: %s", *payload.Status)
+	}
+
+	return nil
+}
+
+func ValidateDeleteActionItemPayload(payload *DeleteActionItemPayload) error {
+	if payload.ActionID == "" {
+		return fmt.Errorf("action item ID is required")
+	}
+
+	return nil
+}
+
+func ValidatePromoteTilePayload(payload *PromoteTilePayload) error {
+	if payload.TileID == "" {
+		return fmt.Errorf("tile ID is required")
+	}
+
+	if err := validateActionOwner(payload.Owner); err != nil {
+		return err
+	}
+
+	return validateDueDate(payload.DueDate)
+}
+
+func ValidateCreateBoardRequest(request *CreateBoardRequest) error {
+	return validateTeamID(request.TeamID)
+}
+
+func validateTeamID(teamID string) error {
+	if len(teamID) > MaxTeamIDLength {
+		return fmt.Errorf("team ID exceeds maximum length of %d characters", MaxTeamIDLength)
+	}
+
+	// Team IDs appear in store keys, so keep them to a safe alphabet
+	for _, r := range teamID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("team ID may only contain letters, digits, '-' and '_'")
+		}
+	}
+
+	return nil
+}
+
+// validateTemplateText checks the name, description and ice-breaker prompt a
+// team gives a stored template
+func validateTemplateText(name, description, prompt string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("template name is required")
+	}
+
+	if !isValidUTF8(name) || !isValidUTF8(description) || !isValidUTF8(prompt) {
+		return fmt.Errorf("template contains invalid UTF-8 characters")
+	}
+
+	if utf8.RuneCountInString(name) > MaxTemplateNameLength {
+		return fmt.Errorf("template name exceeds maximum length of %d characters", MaxTemplateNameLength)
+	}
+
+	if utf8.RuneCountInString(description) > MaxTemplateTextLength {
+		return fmt.Errorf("template description exceeds maximum length of %d characters", MaxTemplateTextLength)
+	}
+
+	if utf8.RuneCountInString(prompt) > MaxTemplateTextLength {
+		return fmt.Errorf("ice-breaker prompt exceeds maximum length of %d characters", MaxTemplateTextLength)
+	}
+
+	return nil
+}
+
+func ValidateSaveTemplateRequest(request *SaveTemplateRequest) error {
+	if err := validateTemplateText(request.Name, request.Description, request.IcebreakerPrompt); err != nil {
+		return err
+	}
+
+	return validateTeamID(request.TeamID)
+}
+
+func ValidateSaveBoardTemplatePayload(payload *SaveBoardTemplatePayload) error {
+	return validateTemplateText(payload.Name, payload.Description, payload.IcebreakerPrompt)
+}
+
+// ValidateTemplate checks the columns, voting settings and phases a board is
+// created with
+func ValidateTemplate(template *Template) error {
+	if len(template.Columns) == 0 {
+		return fmt.Errorf("a template needs at least one column")
+	}
+
+	if len(template.Columns) > MaxTemplateColumns {
+		return fmt.Errorf("a template may have at most %d columns", MaxTemplateColumns)
+	}
+
+	for _, column := range template.Columns {
+		if err := ValidateCreateColumnPayload(&CreateColumnPayload{Title: column.Title}); err != nil {
+			return err
+		}
+
+		if column.Color != "" && !isHexColor(column.Color) {
+			return fmt.Errorf("column color must be a hex color like #3b82f6")
+		}
+	}
+
+	if err := ValidateVotingSettings(&template.Voting); err != nil {
+		return err
+	}
+
+	return validatePhases(template.Phases)
+}
+
+// validatePhases requires a non-empty list of distinct phases in facilitation
+// order, so advancing a board never moves it backwards
+func validatePhases(phases []Phase) error {
+	if len(phases) == 0 {
+		return fmt.Errorf("at least one phase is required")
+	}
+
+	for i, phase := range phases {
+		if !phase.IsValid() {
+			return fmt.Errorf("unknown phase: %s", phase)
+		}
+
+		if i > 0 && !phases[i-1].Before(phase) {
+			return fmt.Errorf("phases must be distinct and in facilitation order")
+		}
+	}
+
+	return nil
+}
+
+// ValidateBoard checks a whole board built outside the hub, such as an
+// imported one, against the limits that apply to each change made live and
+// the server's limit of maxColumns columns per board
+func ValidateBoard(board *Board, maxColumns int) error {
+	if len(board.Columns) == 0 {
+		return fmt.Errorf("a board needs at least one column")
+	}
+
+	if len(board.Columns) > maxColumns {
+		return fmt.Errorf("a board may have at most %d columns", maxColumns)
+	}
+
+	tiles := 0
+	for _, column := range board.Columns {
+		if err := ValidateCreateColumnPayload(&CreateColumnPayload{Title: column.Title}); err != nil {
+			return err
+		}
+
+		if column.Color != "" && !isHexColor(column.Color) {
+			return fmt.Errorf("column color must be a hex color like #3b82f6")
+		}
+
+		for _, tile := range column.Tiles {
+			if err := ValidateCreateTilePayload(&CreateTilePayload{ColumnID: column.ID, Content: tile.Content, Author: tile.Author}); err != nil {
+				return err
+			}
+
+			for _, thread := range tile.Threads {
+				if err := ValidateCreateThreadPayload(&CreateThreadPayload{TileID: tile.ID, Content: thread.Content, Author: thread.Author}); err != nil {
+					return err
+				}
+			}
+		}
+		tiles += len(column.Tiles)
+
+		for _, group := range column.Groups {
+			if err := validateGroupTitle(group.Title); err != nil {
+				return err
+			}
+
+			if len(group.TileIDs) == 0 {
+				return fmt.Errorf("at least one tile is required to form a group")
+			}
+		}
+	}
+
+	if tiles > MaxImportedTiles {
+		return fmt.Errorf("a board may have at most %d tiles", MaxImportedTiles)
+	}
+
+	for _, item := range board.ActionItems {
+		payload := CreateActionItemPayload{Title: item.Title, Owner: item.Owner, DueDate: item.DueDate, Status: item.Status}
+		if err := ValidateCreateActionItemPayload(&payload); err != nil {
+			return err
+		}
+	}
+
+	return ValidateVotingSettings(&board.Voting)
+}
+
+func isHexColor(color string) bool {
+	if len(color) != 4 && len(color) != 7 || color[0] != '#' {
+		return false
+	}
+
+	for _, r := range color[1:] {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F') {
+			return false
+		}
+	}
+
+	return true
+}
+
 func SanitizeString(input string) string {
 	// Remove leading and trailing whitespace
 	input = strings.TrimSpace(input)
@@ -156,6 +639,20 @@ func SanitizeString(input string) string {
 	return input
 }
 
+// SanitizeTemplateColumns sanitizes column titles supplied with a request
+func SanitizeTemplateColumns(columns []TemplateColumn) {
+	for i := range columns {
+		columns[i].Title = SanitizeString(columns[i].Title)
+	}
+}
+
+func SanitizeTemplate(template *Template) {
+	template.Name = SanitizeString(template.Name)
+	template.Description = SanitizeString(template.Description)
+	template.IcebreakerPrompt = SanitizeString(template.IcebreakerPrompt)
+	SanitizeTemplateColumns(template.Columns)
+}
+
 func SanitizeTile(tile *Tile) {
 	tile.Content = SanitizeString(tile.Content)
 	tile.Author = SanitizeString(tile.Author)
@@ -168,6 +665,10 @@ func SanitizeTile(tile *Tile) {
 
 func SanitizeColumn(column *Column) {
 	column.Title = SanitizeString(column.Title)
+
+	for _, group := range column.Groups {
+		group.Title = SanitizeString(group.Title)
+	}
 	
 	for _, tile := range column.Tiles {
 		SanitizeTile(tile)
@@ -178,4 +679,9 @@ func SanitizeBoard(board *Board) {
 	for _, column := range board.Columns {
 		SanitizeColumn(column)
 	}
+
+	for _, item := range board.ActionItems {
+		item.Title = SanitizeString(item.Title)
+		item.Owner = SanitizeString(item.Owner)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"time"
+)
+
+// Role determines which parts of a board a recipient may see
+type Role string
+
+const (
+	RoleAdmin       Role = "admin"
+	RoleParticipant Role = "participant"
+	RoleObserver    Role = "observer"
+)
+
+// ParseRole maps a requested role to a non-admin role. Admin access is never
+// granted from a request parameter; it requires a valid admin key.
+func ParseRole(value string) Role {
+	if value == string(RoleObserver) {
+		return RoleObserver
+	}
+	return RoleParticipant
+}
+
+// CanEdit reports whether the role may send mutating messages
+func (r Role) CanEdit() bool {
+	return r == RoleAdmin || r == RoleParticipant
+}
+
+// Viewer identifies the recipient of a board view
+type Viewer struct {
+	Role   Role
+	UserID string
+}
+
+// ProjectBoard returns a copy of the board as the viewer may see it. The admin
+// key is always stripped, the team key is only shown to admins, and for
+// non-admins hidden tiles are reduced to
+// placeholders carrying only their ID and hidden flag. While the board hides
+// votes, non-admins only see their own votes.
+func ProjectBoard(board *Board, viewer Viewer) *Board {
+	view := &Board{
+		ID:        board.ID,
+		Columns:   make(map[string]*Column, len(board.Columns)),
+		CreatedAt: board.CreatedAt,
+		UpdatedAt: board.UpdatedAt,
+		Version:   board.Version,
+		Voting:    board.VotingSettings(),
+		Phase:     board.CurrentPhase(),
+		Phases:    board.EnabledPhases(),
+		TeamID:    board.TeamID,
+
+		IcebreakerPrompt: board.IcebreakerPrompt,
+
+		TTLMinutes: board.TTLMinutes,
+		KeepUntil:  board.KeepUntil,
+		ExpiresAt:  board.ExpiresAt,
+	}
+
+	if viewer.Role == RoleAdmin {
+		view.TeamKey = board.TeamKey
+	}
+
+	view.ActionItems = make([]*ActionItem, 0, len(board.ActionItems))
+	for _, item := range board.ActionItems {
+		itemCopy := *item
+		view.ActionItems = append(view.ActionItems, &itemCopy)
+	}
+
+	if board.Timer != nil {
+		timer := *board.Timer
+		view.Timer = &timer
+	}
+	now := time.Now()
+	view.ServerTime = &now
+
+	hideVotes := board.VotesHidden() && viewer.Role != RoleAdmin
+	for id, column := range board.Columns {
+		view.Columns[id] = projectColumn(column, viewer, hideVotes)
+	}
+	view.ColumnOrder = board.ColumnIDs()
+
+	return view
+}
+
+func projectColumn(column *Column, viewer Viewer, hideVotes bool) *Column {
+	view := &Column{
+		ID:     column.ID,
+		Title:  column.Title,
+		Order:  column.Order,
+		Color:  column.Color,
+		Tiles:  make([]*Tile, 0, len(column.Tiles)),
+		Groups: make([]*Group, 0, len(column.Groups)),
+		Review: column.Review,
+	}
+
+	for _, tile := range column.Tiles {
+		view.Tiles = append(view.Tiles, projectTile(tile, viewer, hideVotes))
+	}
+
+	for _, group := range column.Groups {
+		view.Groups = append(view.Groups, projectGroup(group, viewer, hideVotes))
+	}
+	if hideVotes {
+		// Totals may only reflect the votes the viewer can see
+		view.RefreshGroupVotes()
+	}
+
+	return view
+}
+
+func projectTile(tile *Tile, viewer Viewer, hideVotes bool) *Tile {
+	if tile.IsHidden && viewer.Role != RoleAdmin {
+		return &Tile{
+			ID:        tile.ID,
+			IsHidden:  true,
+			VoterIDs:  []string{},
+			Threads:   []*Thread{},
+			CreatedAt: tile.CreatedAt,
+		}
+	}
+
+	view := *tile
+	if viewer.Role != RoleAdmin {
+		// Keep cards anonymous: author IDs could be matched against voter IDs
+		view.AuthorID = ""
+	}
+	if hideVotes {
+		view.VoterIDs = ownVotes(tile.VoterIDs, viewer.UserID)
+	} else {
+		view.VoterIDs = append([]string{}, tile.VoterIDs...)
+	}
+	view.Threads = make([]*Thread, 0, len(tile.Threads))
+	for _, thread := range tile.Threads {
+		view.Threads = append(view.Threads, projectThread(thread, viewer))
+	}
+
+	return &view
+}
+
+// projectThread copies a thread, keeping its author anonymous to non-admins
+// like a tile's
+func projectThread(thread *Thread, viewer Viewer) *Thread {
+	view := *thread
+	if viewer.Role != RoleAdmin {
+		view.AuthorID = ""
+	}
+	return &view
+}
+
+func projectGroup(group *Group, viewer Viewer, hideVotes bool) *Group {
+	view := *group
+	view.TileIDs = append([]string{}, group.TileIDs...)
+	if hideVotes {
+		view.VoterIDs = ownVotes(group.VoterIDs, viewer.UserID)
+		view.TotalVotes = len(view.VoterIDs)
+	} else {
+		view.VoterIDs = append([]string{}, group.VoterIDs...)
+	}
+	return &view
+}
+
+// ownVotes returns only the viewer's entries from a voter list
+func ownVotes(voterIDs []string, userID string) []string {
+	own := make([]string, 0, CountVotes(voterIDs, userID))
+	for _, id := range voterIDs {
+		if id == userID && userID != "" {
+			own = append(own, id)
+		}
+	}
+	return own
+}
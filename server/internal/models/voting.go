@@ -0,0 +1,72 @@
+package models
+
+// DefaultVotingSettings gives every participant one vote per tile and no
+// overall budget, matching plain toggle voting
+func DefaultVotingSettings() VotingSettings {
+	return VotingSettings{
+		VotesPerParticipant: 0,
+		MaxVotesPerTile:     1,
+		AllowSelfVote:       true,
+	}
+}
+
+// VotingSettings returns the board's voting settings, or the defaults for a
+// board stored before it had any. Valid settings are never zero.
+func (b *Board) VotingSettings() VotingSettings {
+	if b.Voting == (VotingSettings{}) {
+		return DefaultVotingSettings()
+	}
+	return b.Voting
+}
+
+// PerTileLimit returns how many votes one participant may place on a tile
+func (s VotingSettings) PerTileLimit() int {
+	if s.MaxVotesPerTile <= 0 {
+		return 1
+	}
+	return s.MaxVotesPerTile
+}
+
+// VotesCast counts the votes the user has placed on tiles and groups
+func (b *Board) VotesCast(userID string) int {
+	count := 0
+	for _, column := range b.Columns {
+		for _, tile := range column.Tiles {
+			count += CountVotes(tile.VoterIDs, userID)
+		}
+		for _, group := range column.Groups {
+			count += CountVotes(group.VoterIDs, userID)
+		}
+	}
+	return count
+}
+
+// VotesRemaining summarizes the user's vote budget on the board
+func (b *Board) VotesRemaining(userID string) VotesRemainingPayload {
+	budget := b.VotingSettings().VotesPerParticipant
+	used := b.VotesCast(userID)
+	remaining := -1
+	if budget > 0 {
+		remaining = budget - used
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+
+	return VotesRemainingPayload{
+		Limit:     budget,
+		Used:      used,
+		Remaining: remaining,
+	}
+}
+
+// CountVotes returns how many of the votes in voterIDs belong to the user
+func CountVotes(voterIDs []string, userID string) int {
+	count := 0
+	for _, id := range voterIDs {
+		if id == userID {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,57 @@
+package pubsub
+
+import (
+	"context"
+)
+
+// Message is a payload received on a board's channel
+type Message struct {
+	BoardID string
+	Data    []byte
+}
+
+// Broker fans board events out to every server instance. An instance
+// subscribes to the boards it has clients on and publishes every event it
+// produces, so participants connected to different replicas stay in sync.
+type Broker interface {
+	Publish(ctx context.Context, boardID string, data []byte) error
+	Subscribe(ctx context.Context, boardID string) error
+	Unsubscribe(ctx context.Context, boardID string) error
+	Messages() <-chan Message
+	Close() error
+}
+
+var (
+	_ Broker = (*RedisBroker)(nil)
+	_ Broker = (*LocalBroker)(nil)
+)
+
+// LocalBroker is used when the server runs as a single instance. Events are
+// already delivered to local clients, so publishing is a no-op.
+type LocalBroker struct {
+	messages chan Message
+}
+
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{messages: make(chan Message)}
+}
+
+func (l *LocalBroker) Publish(ctx context.Context, boardID string, data []byte) error {
+	return nil
+}
+
+func (l *LocalBroker) Subscribe(ctx context.Context, boardID string) error {
+	return nil
+}
+
+func (l *LocalBroker) Unsubscribe(ctx context.Context, boardID string) error {
+	return nil
+}
+
+func (l *LocalBroker) Messages() <-chan Message {
+	return l.messages
+}
+
+func (l *LocalBroker) Close() error {
+	return nil
+}
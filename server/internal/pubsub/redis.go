@@ -0,0 +1,89 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const channelPrefix = "board-events:"
+
+// RedisBroker publishes board events on one Redis channel per board
+type RedisBroker struct {
+	client   *redis.Client
+	pubsub   *redis.PubSub
+	messages chan Message
+}
+
+func NewRedisBroker(redisURL string) *RedisBroker {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse Redis URL: %v", err))
+	}
+
+	client := redis.NewClient(opts)
+
+	// Test connection
+	ctx := context.Background()
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		panic(fmt.Sprintf("Failed to connect to Redis: %v", err))
+	}
+
+	b := &RedisBroker{
+		client:   client,
+		pubsub:   client.Subscribe(ctx),
+		messages: make(chan Message, 256),
+	}
+
+	go b.receive()
+
+	return b
+}
+
+func channelName(boardID string) string {
+	return channelPrefix + boardID
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, boardID string, data []byte) error {
+	if err := b.client.Publish(ctx, channelName(boardID), data).Err(); err != nil {
+		return fmt.Errorf("failed to publish board event: %v", err)
+	}
+	return nil
+}
+
+func (b *RedisBroker) Subscribe(ctx context.Context, boardID string) error {
+	if err := b.pubsub.Subscribe(ctx, channelName(boardID)); err != nil {
+		return fmt.Errorf("failed to subscribe to board %s: %v", boardID, err)
+	}
+	return nil
+}
+
+func (b *RedisBroker) Unsubscribe(ctx context.Context, boardID string) error {
+	if err := b.pubsub.Unsubscribe(ctx, channelName(boardID)); err != nil {
+		return fmt.Errorf("failed to unsubscribe from board %s: %v", boardID, err)
+	}
+	return nil
+}
+
+func (b *RedisBroker) Messages() <-chan Message {
+	return b.messages
+}
+
+func (b *RedisBroker) Close() error {
+	b.pubsub.Close()
+	return b.client.Close()
+}
+
+// receive forwards channel messages until the subscription is closed
+func (b *RedisBroker) receive() {
+	defer close(b.messages)
+
+	for msg := range b.pubsub.Channel() {
+		b.messages <- Message{
+			BoardID: strings.TrimPrefix(msg.Channel, channelPrefix),
+			Data:    []byte(msg.Payload),
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"live-retro-server/internal/models"
+)
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// MemoryStore keeps boards in process memory for local development and demos.
+// Boards are stored serialized so callers never share pointers with the store,
+// matching the copy semantics of the Redis backend.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	boards    map[string]memoryEntry
+	teams     map[string]memoryEntry
+	templates map[string]memoryEntry
+	// teamTemplates indexes the IDs of each team's templates
+	teamTemplates map[string]map[string]bool
+	retention     models.Retention
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+func NewMemoryStore(retention models.Retention) *MemoryStore {
+	m := &MemoryStore{
+		boards:        make(map[string]memoryEntry),
+		teams:         make(map[string]memoryEntry),
+		templates:     make(map[string]memoryEntry),
+		teamTemplates: make(map[string]map[string]bool),
+		retention:     retention,
+		done:          make(chan struct{}),
+	}
+
+	// Evict expired boards in the background
+	go m.evictExpired()
+
+	return m
+}
+
+func (m *MemoryStore) SaveBoard(board *models.Board) error {
+	return m.SaveBoardContext(context.Background(), board)
+}
+
+func (m *MemoryStore) SaveBoardContext(ctx context.Context, board *models.Board) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.writeLocked(board)
+}
+
+func (m *MemoryStore) CompareAndSwapBoard(board *models.Board) error {
+	return m.CompareAndSwapBoardContext(context.Background(), board)
+}
+
+func (m *MemoryStore) CompareAndSwapBoardContext(ctx context.Context, board *models.Board) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.boards[board.ID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return ErrBoardNotFound
+	}
+
+	var stored struct {
+		Version int64 `json:"version"`
+	}
+	if err := json.Unmarshal(entry.data, &stored); err != nil {
+		return fmt.Errorf("failed to unmarshal board: %v", err)
+	}
+	if stored.Version != board.Version {
+		return ErrVersionConflict
+	}
+
+	return m.writeLocked(board)
+}
+
+func (m *MemoryStore) CompareAndDeleteBoard(boardID string, version int64) error {
+	return m.CompareAndDeleteBoardContext(context.Background(), boardID, version)
+}
+
+func (m *MemoryStore) CompareAndDeleteBoardContext(ctx context.Context, boardID string, version int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.boards[boardID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return ErrBoardNotFound
+	}
+
+	var stored struct {
+		Version int64 `json:"version"`
+	}
+	if err := json.Unmarshal(entry.data, &stored); err != nil {
+		return fmt.Errorf("failed to unmarshal board: %v", err)
+	}
+	if stored.Version != version {
+		return ErrVersionConflict
+	}
+
+	delete(m.boards, boardID)
+	return nil
+}
+
+// writeLocked bumps the version and stores the board; m.mu must be held
+func (m *MemoryStore) writeLocked(board *models.Board) error {
+	board.UpdatedAt = time.Now()
+	board.ExpiresAt = m.retention.ExpiryOf(board, board.UpdatedAt)
+	board.Version++
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		board.Version--
+		return fmt.Errorf("failed to marshal board: %v", err)
+	}
+
+	m.boards[board.ID] = memoryEntry{data: data, expiresAt: board.ExpiresAt}
+	return nil
+}
+
+func (m *MemoryStore) Retention() models.Retention {
+	return m.retention
+}
+
+func (m *MemoryStore) GetBoard(boardID string) (*models.Board, error) {
+	return m.GetBoardContext(context.Background(), boardID)
+}
+
+func (m *MemoryStore) GetBoardContext(ctx context.Context, boardID string) (*models.Board, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	entry, ok := m.boards[boardID]
+	m.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, ErrBoardNotFound
+	}
+
+	var board models.Board
+	if err := json.Unmarshal(entry.data, &board); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal board: %v", err)
+	}
+
+	return &board, nil
+}
+
+func (m *MemoryStore) BoardExists(boardID string) bool {
+	return m.BoardExistsContext(context.Background(), boardID)
+}
+
+func (m *MemoryStore) BoardExistsContext(ctx context.Context, boardID string) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	m.mu.RLock()
+	entry, ok := m.boards[boardID]
+	m.mu.RUnlock()
+
+	return ok && time.Now().Before(entry.expiresAt)
+}
+
+func (m *MemoryStore) DeleteBoard(boardID string) error {
+	return m.DeleteBoardContext(context.Background(), boardID)
+}
+
+func (m *MemoryStore) DeleteBoardContext(ctx context.Context, boardID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	delete(m.boards, boardID)
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *MemoryStore) ExpiringBoards(before time.Time) ([]string, error) {
+	return m.ExpiringBoardsContext(context.Background(), before)
+}
+
+func (m *MemoryStore) ExpiringBoardsContext(ctx context.Context, before time.Time) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	var ids []string
+	for boardID, entry := range m.boards {
+		if now.Before(entry.expiresAt) && !entry.expiresAt.After(before) {
+			ids = append(ids, boardID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *MemoryStore) GetTeam(teamID string) (*models.Team, error) {
+	return m.GetTeamContext(context.Background(), teamID)
+}
+
+func (m *MemoryStore) GetTeamContext(ctx context.Context, teamID string) (*models.Team, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	entry, ok := m.teams[teamID]
+	m.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, ErrTeamNotFound
+	}
+
+	var team models.Team
+	if err := json.Unmarshal(entry.data, &team); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team: %v", err)
+	}
+
+	return &team, nil
+}
+
+func (m *MemoryStore) SaveTeam(team *models.Team) error {
+	return m.SaveTeamContext(context.Background(), team)
+}
+
+func (m *MemoryStore) SaveTeamContext(ctx context.Context, team *models.Team) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team: %v", err)
+	}
+
+	m.mu.Lock()
+	m.teams[team.ID] = memoryEntry{data: data, expiresAt: time.Now().Add(teamTTL)}
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *MemoryStore) GetTemplate(templateID string) (*models.Template, error) {
+	return m.GetTemplateContext(context.Background(), templateID)
+}
+
+func (m *MemoryStore) GetTemplateContext(ctx context.Context, templateID string) (*models.Template, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	entry, ok := m.templates[templateID]
+	m.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, ErrTemplateNotFound
+	}
+
+	var template models.Template
+	if err := json.Unmarshal(entry.data, &template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal template: %v", err)
+	}
+
+	return &template, nil
+}
+
+func (m *MemoryStore) SaveTemplate(template *models.Template) error {
+	return m.SaveTemplateContext(context.Background(), template)
+}
+
+func (m *MemoryStore) SaveTemplateContext(ctx context.Context, template *models.Template) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template: %v", err)
+	}
+
+	m.mu.Lock()
+	m.templates[template.ID] = memoryEntry{data: data, expiresAt: time.Now().Add(teamTTL)}
+	if m.teamTemplates[template.TeamID] == nil {
+		m.teamTemplates[template.TeamID] = make(map[string]bool)
+	}
+	m.teamTemplates[template.TeamID][template.ID] = true
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteTemplate(templateID string) error {
+	return m.DeleteTemplateContext(context.Background(), templateID)
+}
+
+func (m *MemoryStore) DeleteTemplateContext(ctx context.Context, templateID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.deleteTemplateLocked(templateID)
+	m.mu.Unlock()
+
+	return nil
+}
+
+// deleteTemplateLocked removes a template and its entry in the team index.
+// Callers must hold m.mu for writing.
+func (m *MemoryStore) deleteTemplateLocked(templateID string) {
+	entry, ok := m.templates[templateID]
+	if !ok {
+		return
+	}
+	delete(m.templates, templateID)
+
+	// Stored templates always unmarshal; they were marshaled on save
+	var template models.Template
+	if err := json.Unmarshal(entry.data, &template); err != nil {
+		return
+	}
+	delete(m.teamTemplates[template.TeamID], templateID)
+	if len(m.teamTemplates[template.TeamID]) == 0 {
+		delete(m.teamTemplates, template.TeamID)
+	}
+}
+
+func (m *MemoryStore) ListTeamTemplates(teamID string) ([]*models.Template, error) {
+	return m.ListTeamTemplatesContext(context.Background(), teamID)
+}
+
+func (m *MemoryStore) ListTeamTemplatesContext(ctx context.Context, teamID string) ([]*models.Template, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	templates := make([]*models.Template, 0, len(m.teamTemplates[teamID]))
+	for templateID := range m.teamTemplates[teamID] {
+		entry := m.templates[templateID]
+		if now.After(entry.expiresAt) {
+			continue
+		}
+
+		var template models.Template
+		if err := json.Unmarshal(entry.data, &template); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal template: %v", err)
+		}
+		templates = append(templates, &template)
+	}
+
+	sortTemplates(templates)
+	return templates, nil
+}
+
+func (m *MemoryStore) Close() error {
+	m.closeOnce.Do(func() { close(m.done) })
+	return nil
+}
+
+// evictExpired periodically drops boards whose TTL has passed
+func (m *MemoryStore) evictExpired() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			for boardID, entry := range m.boards {
+				if now.After(entry.expiresAt) {
+					delete(m.boards, boardID)
+				}
+			}
+			for teamID, entry := range m.teams {
+				if now.After(entry.expiresAt) {
+					delete(m.teams, teamID)
+				}
+			}
+			for templateID, entry := range m.templates {
+				if now.After(entry.expiresAt) {
+					m.deleteTemplateLocked(templateID)
+				}
+			}
+			m.mu.Unlock()
+		}
+	}
+}
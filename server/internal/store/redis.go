@@ -3,7 +3,9 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -11,18 +13,19 @@ import (
 )
 
 type RedisStore struct {
-	client *redis.Client
-	ctx    context.Context
+	client    *redis.Client
+	ctx       context.Context
+	retention models.Retention
 }
 
-func NewRedisStore(redisURL string) *RedisStore {
+func NewRedisStore(redisURL string, retention models.Retention) *RedisStore {
 	opts, err := redis.ParseURL(redisURL)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to parse Redis URL: %v", err))
 	}
 
 	client := redis.NewClient(opts)
-	
+
 	// Test connection
 	ctx := context.Background()
 	_, err = client.Ping(ctx).Result()
@@ -31,37 +34,161 @@ func NewRedisStore(redisURL string) *RedisStore {
 	}
 
 	return &RedisStore{
-		client: client,
-		ctx:    ctx,
+		client:    client,
+		ctx:       ctx,
+		retention: retention,
 	}
 }
 
+func (r *RedisStore) Retention() models.Retention {
+	return r.retention
+}
+
+func boardKey(boardID string) string {
+	return fmt.Sprintf("board:%s", boardID)
+}
+
+// boardExpiryKey is a sorted set of board IDs scored by when each board
+// expires, so boards close to expiry can be found without scanning
+const boardExpiryKey = "board-expiries"
+
+func boardExpiry(board *models.Board) redis.Z {
+	return redis.Z{Score: float64(board.ExpiresAt.Unix()), Member: board.ID}
+}
+
 func (r *RedisStore) SaveBoard(board *models.Board) error {
+	return r.SaveBoardContext(r.ctx, board)
+}
+
+func (r *RedisStore) SaveBoardContext(ctx context.Context, board *models.Board) error {
 	board.UpdatedAt = time.Now()
-	
+	board.ExpiresAt = r.retention.ExpiryOf(board, board.UpdatedAt)
+	board.Version++
+
 	data, err := json.Marshal(board)
 	if err != nil {
+		board.Version--
 		return fmt.Errorf("failed to marshal board: %v", err)
 	}
 
-	key := fmt.Sprintf("board:%s", board.ID)
-	
-	// Save board and reset TTL to 30 minutes
-	err = r.client.Set(r.ctx, key, data, 30*time.Minute).Err()
-	if err != nil {
+	// Save board and reset TTL
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, boardKey(board.ID), data, board.ExpiresAt.Sub(board.UpdatedAt))
+	pipe.ZAdd(ctx, boardExpiryKey, boardExpiry(board))
+	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to save board to Redis: %v", err)
 	}
 
 	return nil
 }
 
+func (r *RedisStore) CompareAndSwapBoard(board *models.Board) error {
+	return r.CompareAndSwapBoardContext(r.ctx, board)
+}
+
+// CompareAndSwapBoardContext uses WATCH/MULTI so the write is discarded if the
+// key changes between the version check and the SET
+func (r *RedisStore) CompareAndSwapBoardContext(ctx context.Context, board *models.Board) error {
+	key := boardKey(board.ID)
+	expected := board.Version
+
+	board.UpdatedAt = time.Now()
+	board.ExpiresAt = r.retention.ExpiryOf(board, board.UpdatedAt)
+	board.Version = expected + 1
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		board.Version = expected
+		return fmt.Errorf("failed to marshal board: %v", err)
+	}
+
+	err = r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			if err == redis.Nil {
+				return ErrBoardNotFound
+			}
+			return fmt.Errorf("failed to get board from Redis: %v", err)
+		}
+
+		var stored struct {
+			Version int64 `json:"version"`
+		}
+		if err := json.Unmarshal(current, &stored); err != nil {
+			return fmt.Errorf("failed to unmarshal board: %v", err)
+		}
+		if stored.Version != expected {
+			return ErrVersionConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, board.ExpiresAt.Sub(board.UpdatedAt))
+			pipe.ZAdd(ctx, boardExpiryKey, boardExpiry(board))
+			return nil
+		})
+		return err
+	}, key)
+
+	if err != nil {
+		board.Version = expected
+		if err == redis.TxFailedErr {
+			return ErrVersionConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *RedisStore) CompareAndDeleteBoard(boardID string, version int64) error {
+	return r.CompareAndDeleteBoardContext(r.ctx, boardID, version)
+}
+
+func (r *RedisStore) CompareAndDeleteBoardContext(ctx context.Context, boardID string, version int64) error {
+	key := boardKey(boardID)
+
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			if err == redis.Nil {
+				return ErrBoardNotFound
+			}
+			return fmt.Errorf("failed to get board from Redis: %v", err)
+		}
+
+		var stored struct {
+			Version int64 `json:"version"`
+		}
+		if err := json.Unmarshal(current, &stored); err != nil {
+			return fmt.Errorf("failed to unmarshal board: %v", err)
+		}
+		if stored.Version != version {
+			return ErrVersionConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, key)
+			pipe.ZRem(ctx, boardExpiryKey, boardID)
+			return nil
+		})
+		return err
+	}, key)
+
+	if err == redis.TxFailedErr {
+		return ErrVersionConflict
+	}
+	return err
+}
+
 func (r *RedisStore) GetBoard(boardID string) (*models.Board, error) {
-	key := fmt.Sprintf("board:%s", boardID)
-	
-	data, err := r.client.Get(r.ctx, key).Result()
+	return r.GetBoardContext(r.ctx, boardID)
+}
+
+func (r *RedisStore) GetBoardContext(ctx context.Context, boardID string) (*models.Board, error) {
+	data, err := r.client.Get(ctx, boardKey(boardID)).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return nil, fmt.Errorf("board not found")
+			return nil, ErrBoardNotFound
 		}
 		return nil, fmt.Errorf("failed to get board from Redis: %v", err)
 	}
@@ -76,8 +203,11 @@ func (r *RedisStore) GetBoard(boardID string) (*models.Board, error) {
 }
 
 func (r *RedisStore) BoardExists(boardID string) bool {
-	key := fmt.Sprintf("board:%s", boardID)
-	exists, err := r.client.Exists(r.ctx, key).Result()
+	return r.BoardExistsContext(r.ctx, boardID)
+}
+
+func (r *RedisStore) BoardExistsContext(ctx context.Context, boardID string) bool {
+	exists, err := r.client.Exists(ctx, boardKey(boardID)).Result()
 	if err != nil {
 		return false
 	}
@@ -85,14 +215,207 @@ func (r *RedisStore) BoardExists(boardID string) bool {
 }
 
 func (r *RedisStore) DeleteBoard(boardID string) error {
-	key := fmt.Sprintf("board:%s", boardID)
-	err := r.client.Del(r.ctx, key).Err()
-	if err != nil {
+	return r.DeleteBoardContext(r.ctx, boardID)
+}
+
+func (r *RedisStore) DeleteBoardContext(ctx context.Context, boardID string) error {
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, boardKey(boardID))
+	pipe.ZRem(ctx, boardExpiryKey, boardID)
+	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to delete board from Redis: %v", err)
 	}
 	return nil
 }
 
+func (r *RedisStore) ExpiringBoards(before time.Time) ([]string, error) {
+	return r.ExpiringBoardsContext(r.ctx, before)
+}
+
+func (r *RedisStore) ExpiringBoardsContext(ctx context.Context, before time.Time) ([]string, error) {
+	// Boards that already expired have left Redis; drop them from the index
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := r.client.ZRemRangeByScore(ctx, boardExpiryKey, "-inf", "("+now).Err(); err != nil {
+		return nil, fmt.Errorf("failed to prune board expiries in Redis: %v", err)
+	}
+
+	ids, err := r.client.ZRangeByScore(ctx, boardExpiryKey, &redis.ZRangeBy{
+		Min: now,
+		Max: strconv.FormatInt(before.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring boards from Redis: %v", err)
+	}
+	return ids, nil
+}
+
+func teamKey(teamID string) string {
+	return fmt.Sprintf("team:%s", teamID)
+}
+
+func (r *RedisStore) GetTeam(teamID string) (*models.Team, error) {
+	return r.GetTeamContext(r.ctx, teamID)
+}
+
+func (r *RedisStore) GetTeamContext(ctx context.Context, teamID string) (*models.Team, error) {
+	data, err := r.client.Get(ctx, teamKey(teamID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team from Redis: %v", err)
+	}
+
+	var team models.Team
+	if err := json.Unmarshal([]byte(data), &team); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team: %v", err)
+	}
+
+	return &team, nil
+}
+
+func (r *RedisStore) SaveTeam(team *models.Team) error {
+	return r.SaveTeamContext(r.ctx, team)
+}
+
+func (r *RedisStore) SaveTeamContext(ctx context.Context, team *models.Team) error {
+	data, err := json.Marshal(team)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team: %v", err)
+	}
+
+	if err := r.client.Set(ctx, teamKey(team.ID), data, teamTTL).Err(); err != nil {
+		return fmt.Errorf("failed to save team to Redis: %v", err)
+	}
+
+	return nil
+}
+
+func templateKey(templateID string) string {
+	return fmt.Sprintf("template:%s", templateID)
+}
+
+// teamTemplatesKey holds the IDs of a team's templates so they can be listed
+// without scanning the keyspace
+func teamTemplatesKey(teamID string) string {
+	return fmt.Sprintf("team:%s:templates", teamID)
+}
+
+func (r *RedisStore) GetTemplate(templateID string) (*models.Template, error) {
+	return r.GetTemplateContext(r.ctx, templateID)
+}
+
+func (r *RedisStore) GetTemplateContext(ctx context.Context, templateID string) (*models.Template, error) {
+	data, err := r.client.Get(ctx, templateKey(templateID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get template from Redis: %v", err)
+	}
+
+	var template models.Template
+	if err := json.Unmarshal([]byte(data), &template); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal template: %v", err)
+	}
+
+	return &template, nil
+}
+
+func (r *RedisStore) SaveTemplate(template *models.Template) error {
+	return r.SaveTemplateContext(r.ctx, template)
+}
+
+func (r *RedisStore) SaveTemplateContext(ctx context.Context, template *models.Template) error {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template: %v", err)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, templateKey(template.ID), data, teamTTL)
+	pipe.SAdd(ctx, teamTemplatesKey(template.TeamID), template.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save template to Redis: %v", err)
+	}
+
+	return nil
+}
+
+func (r *RedisStore) DeleteTemplate(templateID string) error {
+	return r.DeleteTemplateContext(r.ctx, templateID)
+}
+
+func (r *RedisStore) DeleteTemplateContext(ctx context.Context, templateID string) error {
+	template, err := r.GetTemplateContext(ctx, templateID)
+	if errors.Is(err, ErrTemplateNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, templateKey(templateID))
+	pipe.SRem(ctx, teamTemplatesKey(template.TeamID), templateID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete template from Redis: %v", err)
+	}
+
+	return nil
+}
+
+func (r *RedisStore) ListTeamTemplates(teamID string) ([]*models.Template, error) {
+	return r.ListTeamTemplatesContext(r.ctx, teamID)
+}
+
+func (r *RedisStore) ListTeamTemplatesContext(ctx context.Context, teamID string) ([]*models.Template, error) {
+	ids, err := r.client.SMembers(ctx, teamTemplatesKey(teamID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates from Redis: %v", err)
+	}
+
+	templates := make([]*models.Template, 0, len(ids))
+	if len(ids) == 0 {
+		return templates, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = templateKey(id)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get templates from Redis: %v", err)
+	}
+
+	var expired []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			// Expired, or deleted between SMEMBERS and MGET
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		var template models.Template
+		if err := json.Unmarshal([]byte(data), &template); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal template: %v", err)
+		}
+		templates = append(templates, &template)
+	}
+
+	// Keys expire on their own, but their IDs stay in the index until pruned;
+	// a failed prune is retried by the next listing
+	if len(expired) > 0 {
+		r.client.SRem(ctx, teamTemplatesKey(teamID), expired...)
+	}
+
+	sortTemplates(templates)
+	return templates, nil
+}
+
 func (r *RedisStore) Close() error {
 	return r.client.Close()
-}
\ No newline at end of file
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+
+	"live-retro-server/internal/models"
+)
+
+// teamTTL is how long a team's carried-over action items are kept after its
+// last retro
+const teamTTL = 90 * 24 * time.Hour
+
+// maxUpdateAttempts bounds how often UpdateBoard retries after a conflict
+const maxUpdateAttempts = 8
+
+var (
+	// ErrBoardNotFound is returned when a board does not exist or has expired
+	ErrBoardNotFound = errors.New("board not found")
+
+	// ErrVersionConflict is returned by CompareAndSwapBoard when the stored
+	// board was modified after it was read
+	ErrVersionConflict = errors.New("board was modified concurrently")
+
+	// ErrTeamNotFound is returned when a team has no recorded retro
+	ErrTeamNotFound = errors.New("team not found")
+
+	// ErrTemplateNotFound is returned when a stored template does not exist
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+// BoardStore persists boards. The plain methods use the store's background
+// context; the *Context variants let callers bound or cancel the operation.
+type BoardStore interface {
+	GetBoard(boardID string) (*models.Board, error)
+	SaveBoard(board *models.Board) error
+	BoardExists(boardID string) bool
+	DeleteBoard(boardID string) error
+
+	GetBoardContext(ctx context.Context, boardID string) (*models.Board, error)
+	SaveBoardContext(ctx context.Context, board *models.Board) error
+	BoardExistsContext(ctx context.Context, boardID string) bool
+	DeleteBoardContext(ctx context.Context, boardID string) error
+
+	// CompareAndSwapBoard saves the board only if the stored version still
+	// matches board.Version, and increments the version on success.
+	CompareAndSwapBoard(board *models.Board) error
+	CompareAndSwapBoardContext(ctx context.Context, board *models.Board) error
+
+	// CompareAndDeleteBoard deletes the board only if the stored version
+	// still matches version
+	CompareAndDeleteBoard(boardID string, version int64) error
+	CompareAndDeleteBoardContext(ctx context.Context, boardID string, version int64) error
+
+	// ExpiringBoards lists the boards that expire before the given time and
+	// have not expired yet
+	ExpiringBoards(before time.Time) ([]string, error)
+	ExpiringBoardsContext(ctx context.Context, before time.Time) ([]string, error)
+
+	// Retention reports the limits the store expires boards by. Every save
+	// sets the board's ExpiresAt from them.
+	Retention() models.Retention
+
+	// Teams outlive their boards so open action items can carry over
+	GetTeam(teamID string) (*models.Team, error)
+	SaveTeam(team *models.Team) error
+	GetTeamContext(ctx context.Context, teamID string) (*models.Team, error)
+	SaveTeamContext(ctx context.Context, team *models.Team) error
+
+	// Templates belong to a team and expire like teams do, teamTTL after
+	// they were last saved; ListTeamTemplates returns a team's templates
+	// sorted by name
+	GetTemplate(templateID string) (*models.Template, error)
+	SaveTemplate(template *models.Template) error
+	DeleteTemplate(templateID string) error
+	ListTeamTemplates(teamID string) ([]*models.Template, error)
+	GetTemplateContext(ctx context.Context, templateID string) (*models.Template, error)
+	SaveTemplateContext(ctx context.Context, template *models.Template) error
+	DeleteTemplateContext(ctx context.Context, templateID string) error
+	ListTeamTemplatesContext(ctx context.Context, teamID string) ([]*models.Template, error)
+
+	Close() error
+}
+
+var (
+	_ BoardStore = (*RedisStore)(nil)
+	_ BoardStore = (*MemoryStore)(nil)
+)
+
+// sortTemplates orders templates by name, breaking ties by ID
+func sortTemplates(templates []*models.Template) {
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].ID < templates[j].ID
+	})
+}
+
+// UpdateBoard loads a board, applies mutate and saves it with a
+// compare-and-swap. If another writer got there first the board is reloaded
+// and mutate runs again, so mutate must be safe to repeat. An error returned
+// by mutate aborts the update and is passed back unchanged.
+func UpdateBoard(ctx context.Context, s BoardStore, boardID string, mutate func(board *models.Board) error) (*models.Board, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		board, err := s.GetBoardContext(ctx, boardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := mutate(board); err != nil {
+			return nil, err
+		}
+
+		err = s.CompareAndSwapBoardContext(ctx, board)
+		if err == nil {
+			return board, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) {
+			return nil, err
+		}
+
+		if err := backOff(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, ErrVersionConflict
+}
+
+// DeleteBoard removes a board after handing the version it removes to
+// finish, such as for archiving, so a change saved in between is never lost:
+// finish runs again with the newer version. If finish fails the board is
+// kept.
+func DeleteBoard(ctx context.Context, s BoardStore, boardID string, finish func(board *models.Board) error) (*models.Board, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		board, err := s.GetBoardContext(ctx, boardID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := finish(board); err != nil {
+			return nil, err
+		}
+
+		err = s.CompareAndDeleteBoardContext(ctx, boardID, board.Version)
+		if err == nil {
+			return board, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) {
+			return nil, err
+		}
+
+		if err := backOff(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, ErrVersionConflict
+}
+
+// backOff waits briefly, with jitter, before another attempt after a conflict
+// so competing writers spread out
+func backOff(ctx context.Context, attempt int) error {
+	backoff := time.Duration(attempt+1)*5*time.Millisecond + time.Duration(rand.Intn(5))*time.Millisecond
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(backoff):
+		return nil
+	}
+}
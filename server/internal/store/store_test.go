@@ -0,0 +1,240 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"live-retro-server/internal/models"
+)
+
+// conflictingStore saves a competing change to the board before each of the
+// first conflicts compare-and-swaps, as another writer would
+type conflictingStore struct {
+	*MemoryStore
+	conflicts int
+}
+
+func (s *conflictingStore) CompareAndSwapBoardContext(ctx context.Context, board *models.Board) error {
+	if s.conflicts > 0 {
+		s.conflicts--
+		competing, err := s.GetBoardContext(ctx, board.ID)
+		if err != nil {
+			return err
+		}
+		competing.IcebreakerPrompt += "+"
+		if err := s.MemoryStore.CompareAndSwapBoardContext(ctx, competing); err != nil {
+			return err
+		}
+	}
+	return s.MemoryStore.CompareAndSwapBoardContext(ctx, board)
+}
+
+func newTestStore(t *testing.T) *MemoryStore {
+	t.Helper()
+	s := NewMemoryStore(models.DefaultRetention())
+	t.Cleanup(func() { s.Close() })
+
+	if err := s.SaveBoard(&models.Board{ID: "board", Columns: map[string]*models.Column{}}); err != nil {
+		t.Fatalf("SaveBoard: %v", err)
+	}
+	return s
+}
+
+func TestCompareAndSwapBoard(t *testing.T) {
+	tests := []struct {
+		name    string
+		boardID string
+		stale   bool
+		want    error
+	}{
+		{name: "current version", boardID: "board"},
+		{name: "stale version", boardID: "board", stale: true, want: ErrVersionConflict},
+		{name: "missing board", boardID: "missing", want: ErrBoardNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			board, err := s.GetBoard("board")
+			if err != nil {
+				t.Fatalf("GetBoard: %v", err)
+			}
+			board.ID = tt.boardID
+			if tt.stale {
+				board.Version--
+			}
+			version := board.Version
+
+			err = s.CompareAndSwapBoard(board)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("CompareAndSwapBoard = %v, want %v", err, tt.want)
+			}
+			if err == nil && board.Version != version+1 {
+				t.Errorf("version = %d, want %d", board.Version, version+1)
+			}
+			if err != nil && board.Version != version {
+				t.Errorf("a failed swap changed the version to %d", board.Version)
+			}
+		})
+	}
+}
+
+func TestUpdateBoard(t *testing.T) {
+	errRejected := errors.New("rejected")
+
+	tests := []struct {
+		name       string
+		boardID    string
+		conflicts  int
+		reject     bool
+		want       error
+		wantPrompt string
+		wantCalls  int
+	}{
+		{name: "no conflict", boardID: "board", wantPrompt: "!", wantCalls: 1},
+		{name: "retries after a conflict", boardID: "board", conflicts: 1, wantPrompt: "+!", wantCalls: 2},
+		{name: "retries after several conflicts", boardID: "board", conflicts: 3, wantPrompt: "+++!", wantCalls: 4},
+		{name: "gives up after repeated conflicts", boardID: "board", conflicts: maxUpdateAttempts, want: ErrVersionConflict, wantPrompt: "++++++++", wantCalls: maxUpdateAttempts},
+		{name: "mutate error", boardID: "board", reject: true, want: errRejected, wantCalls: 1},
+		{name: "missing board", boardID: "missing", want: ErrBoardNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &conflictingStore{MemoryStore: newTestStore(t), conflicts: tt.conflicts}
+
+			calls := 0
+			updated, err := UpdateBoard(context.Background(), s, tt.boardID, func(board *models.Board) error {
+				calls++
+				if tt.reject {
+					return errRejected
+				}
+				board.IcebreakerPrompt += "!"
+				return nil
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("UpdateBoard = %v, want %v", err, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("mutate ran %d times, want %d", calls, tt.wantCalls)
+			}
+			if err == nil && updated.IcebreakerPrompt != tt.wantPrompt {
+				t.Errorf("returned prompt = %q, want %q", updated.IcebreakerPrompt, tt.wantPrompt)
+			}
+
+			if tt.boardID != "board" {
+				return
+			}
+			stored, err := s.GetBoard("board")
+			if err != nil {
+				t.Fatalf("GetBoard: %v", err)
+			}
+			if stored.IcebreakerPrompt != tt.wantPrompt {
+				t.Errorf("stored prompt = %q, want %q", stored.IcebreakerPrompt, tt.wantPrompt)
+			}
+		})
+	}
+}
+
+func TestDeleteBoard(t *testing.T) {
+	tests := []struct {
+		name        string
+		changes     int
+		fail        bool
+		wantErr     bool
+		wantPrompts []string
+	}{
+		{name: "unchanged", wantPrompts: []string{""}},
+		{name: "changed while finishing", changes: 1, wantPrompts: []string{"", "+"}},
+		{name: "finish fails", fail: true, wantErr: true, wantPrompts: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			changes := tt.changes
+
+			var prompts []string
+			_, err := DeleteBoard(context.Background(), s, "board", func(board *models.Board) error {
+				prompts = append(prompts, board.IcebreakerPrompt)
+				if tt.fail {
+					return errors.New("archive failed")
+				}
+				if changes > 0 {
+					changes--
+					if _, err := UpdateBoard(context.Background(), s, "board", func(board *models.Board) error {
+						board.IcebreakerPrompt += "+"
+						return nil
+					}); err != nil {
+						t.Fatalf("UpdateBoard: %v", err)
+					}
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteBoard = %v, want error %v", err, tt.wantErr)
+			}
+			if len(prompts) != len(tt.wantPrompts) {
+				t.Fatalf("finish saw %q, want %q", prompts, tt.wantPrompts)
+			}
+			for i := range prompts {
+				if prompts[i] != tt.wantPrompts[i] {
+					t.Errorf("finish saw %q, want %q", prompts, tt.wantPrompts)
+				}
+			}
+
+			if exists := s.BoardExists("board"); exists != tt.wantErr {
+				t.Errorf("board exists = %v, want %v", exists, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListTeamTemplates(t *testing.T) {
+	s := newTestStore(t)
+
+	saved := []*models.Template{
+		{ID: "b", Name: "Sprint", TeamID: "team"},
+		{ID: "a", Name: "Kickoff", TeamID: "team"},
+		{ID: "c", Name: "Other", TeamID: "other"},
+	}
+	for _, template := range saved {
+		if err := s.SaveTemplate(template); err != nil {
+			t.Fatalf("SaveTemplate: %v", err)
+		}
+	}
+	if err := s.DeleteTemplate("b"); err != nil {
+		t.Fatalf("DeleteTemplate: %v", err)
+	}
+
+	tests := []struct {
+		teamID string
+		want   []string
+	}{
+		{teamID: "team", want: []string{"a"}},
+		{teamID: "other", want: []string{"c"}},
+		{teamID: "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.teamID, func(t *testing.T) {
+			templates, err := s.ListTeamTemplates(tt.teamID)
+			if err != nil {
+				t.Fatalf("ListTeamTemplates: %v", err)
+			}
+			var got []string
+			for _, template := range templates {
+				got = append(got, template.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("templates = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("templates = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}